@@ -90,9 +90,18 @@ func decodeNumber(data interface{}) (decimal.Decimal, error) {
 	return dec, nil
 }
 
+// redactedParams are never written to the debug log.
+var redactedParams = map[string]bool{
+	"password": true,
+}
+
 func debugParams(params map[string]interface{}) string {
 	var s []string
 	for k, v := range params {
+		if redactedParams[k] {
+			s = append(s, k+"=[redacted]")
+			continue
+		}
 		r := reflect.ValueOf(v)
 		if r.Kind() == reflect.Ptr {
 			if r.IsNil() {
@@ -735,3 +744,23 @@ func (d *Client) WalletRemove(id string) (*Wallet, error) {
 	response := new(Wallet)
 	return response, d.call(response, "wallet_remove", map[string]interface{}{"wallet_id": id})
 }
+
+// WalletUnlock unlocks an encrypted wallet so it can sign transactions. The default wallet is used if id is empty.
+func (d *Client) WalletUnlock(id string, password string) (bool, error) {
+	var response bool
+	params := map[string]interface{}{"password": password}
+	if id != "" {
+		params["wallet_id"] = id
+	}
+	return response, d.call(&response, "wallet_unlock", params)
+}
+
+// WalletLock locks an encrypted wallet that was unlocked with WalletUnlock. The default wallet is used if id is empty.
+func (d *Client) WalletLock(id string) (bool, error) {
+	var response bool
+	params := map[string]interface{}{}
+	if id != "" {
+		params["wallet_id"] = id
+	}
+	return response, d.call(&response, "wallet_lock", params)
+}
@@ -812,3 +812,14 @@ func TestClient_TransactionSummary(t *testing.T) {
 		t.Error("found wrong lbc amount for transaction.")
 	}
 }
+
+func TestDebugParamsRedactsPassword(t *testing.T) {
+	walletID := "wallet"
+	s := debugParams(map[string]interface{}{"password": "hunter2", "wallet_id": &walletID})
+	if strings.Contains(s, "hunter2") {
+		t.Errorf("password was not redacted: %s", s)
+	}
+	if s != "password=[redacted] wallet_id=wallet" {
+		t.Errorf("unexpected debug params: %s", s)
+	}
+}
@@ -0,0 +1,88 @@
+package jsonrpc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// MinDaemonVersion is the oldest lbrynet whose API the requests and responses of this client are written against.
+const MinDaemonVersion = "0.100.0"
+
+// ErrDaemonTooOld is returned by CheckDaemonVersion when the daemon is older than the version asked for.
+type ErrDaemonTooOld struct {
+	Version    string
+	MinVersion string
+}
+
+func (e ErrDaemonTooOld) Error() string {
+	return fmt.Sprintf("lbrynet %s is too old, at least %s is needed", e.Version, e.MinVersion)
+}
+
+// CheckDaemonVersion asks the daemon for its version and returns ErrDaemonTooOld if it is older than minVersion, which
+// is usually MinDaemonVersion. A pre-release such as 0.100.0rc1 is older than its release.
+func (d *Client) CheckDaemonVersion(minVersion string) error {
+	minimum, err := parseVersion(minVersion)
+	if err != nil {
+		return err
+	}
+	response, err := d.Version()
+	if err != nil {
+		return err
+	}
+	version, err := parseVersion(response.LbrynetVersion)
+	if err != nil {
+		return errors.Prefix("daemon version", err)
+	}
+
+	if version.less(minimum) {
+		return errors.Err(ErrDaemonTooOld{Version: response.LbrynetVersion, MinVersion: minVersion})
+	}
+	return nil
+}
+
+// daemonVersion is a parsed major.minor.patch version.
+type daemonVersion struct {
+	parts [3]int
+	// preRelease is set if something such as rc1 follows the numbers.
+	preRelease bool
+}
+
+// less reports whether v is older than other. Pre-releases of the same version aren't ordered among themselves.
+func (v daemonVersion) less(other daemonVersion) bool {
+	for i := range v.parts {
+		if v.parts[i] != other.parts[i] {
+			return v.parts[i] < other.parts[i]
+		}
+	}
+	return v.preRelease && !other.preRelease
+}
+
+// parseVersion parses a major.minor.patch version, with an optional v in front. Missing parts are 0 and anything after
+// the numbers of the last part, such as rc1, marks a pre-release.
+func parseVersion(version string) (daemonVersion, error) {
+	var parsed daemonVersion
+	trimmed := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	parts := strings.Split(trimmed, ".")
+	if trimmed == "" || len(parts) > 3 {
+		return parsed, errors.Err("'%s' is not a version", version)
+	}
+	for i, part := range parts {
+		digits := part
+		if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+			digits = part[:end]
+		}
+		if i < len(parts)-1 && digits != part {
+			return parsed, errors.Err("'%s' is not a version", version)
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return parsed, errors.Err("'%s' is not a version", version)
+		}
+		parsed.parts[i] = n
+		parsed.preRelease = digits != part
+	}
+	return parsed, nil
+}
@@ -0,0 +1,83 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+func versionServer(t *testing.T, version string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != "version" {
+			t.Errorf("unexpected request %+v: %v", request, err)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  map[string]interface{}{"lbrynet_version": version},
+		})
+	}))
+}
+
+func TestCheckDaemonVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		tooOld  bool
+	}{
+		{"0.113.0", false},
+		{"0.100.0", false},
+		{"v0.100.1", false},
+		{"1.0.0", false},
+		{"0.99.9", true},
+		{"0.9.200", true},
+		{"0.100.0rc1", true},
+		{"0.100.1rc1", false},
+	}
+	for _, test := range tests {
+		server := versionServer(t, test.version)
+		err := NewClient(server.URL).CheckDaemonVersion(MinDaemonVersion)
+		server.Close()
+
+		_, tooOld := errors.Unwrap(err).(ErrDaemonTooOld)
+		if tooOld != test.tooOld {
+			t.Errorf("%s: expected too old to be %t, got %v", test.version, test.tooOld, err)
+		}
+		if !test.tooOld && err != nil {
+			t.Errorf("%s: %s", test.version, err)
+		}
+	}
+
+	server := versionServer(t, "unknown")
+	defer server.Close()
+	if err := NewClient(server.URL).CheckDaemonVersion(MinDaemonVersion); err == nil {
+		t.Error("expected an error for a version that can't be parsed")
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		parsed  daemonVersion
+		valid   bool
+	}{
+		{"0.113.0", daemonVersion{parts: [3]int{0, 113, 0}}, true},
+		{"v1.2", daemonVersion{parts: [3]int{1, 2, 0}}, true},
+		{"0.48.0rc1", daemonVersion{parts: [3]int{0, 48, 0}, preRelease: true}, true},
+		{"", daemonVersion{}, false},
+		{"1.2.3.4", daemonVersion{}, false},
+		{"1.x.3", daemonVersion{}, false},
+	}
+	for _, test := range tests {
+		parsed, err := parseVersion(test.version)
+		if (err == nil) != test.valid || (test.valid && parsed != test.parsed) {
+			t.Errorf("%s: expected %v (valid %t), got %v, %v", test.version, test.parsed, test.valid, parsed, err)
+		}
+	}
+}
@@ -1,11 +1,8 @@
 package lbrycrd
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
-	"encoding/hex"
-
-	"golang.org/x/crypto/ripemd160"
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbry.go/v2/schema/stake"
 )
 
 // rev reverses a byte slice. useful for switching endian-ness
@@ -17,31 +14,10 @@ func rev(b []byte) []byte {
 	return r
 }
 
+// ClaimIDFromOutpoint is a shorthand for stake.ClaimIDFromOutpoint that takes an int nout.
 func ClaimIDFromOutpoint(txid string, nout int) (string, error) {
-	// convert transaction id to byte array
-	txidBytes, err := hex.DecodeString(txid)
-	if err != nil {
-		return "", err
+	if nout < 0 {
+		return "", errors.Err("nout %d is negative", nout)
 	}
-
-	// reverse (make big-endian)
-	txidBytes = rev(txidBytes)
-
-	// append nout
-	noutBytes := make([]byte, 4) // num bytes in uint32
-	binary.BigEndian.PutUint32(noutBytes, uint32(nout))
-	txidBytes = append(txidBytes, noutBytes...)
-
-	// sha256 it
-	s := sha256.New()
-	s.Write(txidBytes)
-
-	// ripemd it
-	r := ripemd160.New()
-	r.Write(s.Sum(nil))
-
-	// reverse (make little-endian)
-	res := rev(r.Sum(nil))
-
-	return hex.EncodeToString(res), nil
+	return c.ClaimIDFromOutpoint(txid, uint32(nout))
 }
@@ -2,32 +2,20 @@ package lbrycrd
 
 import (
 	"github.com/btcsuite/btcd/btcec"
-	"github.com/lbryio/lbry.go/v2/extras/errors"
-	"github.com/lbryio/lbry.go/v2/schema/keys"
 	c "github.com/lbryio/lbry.go/v2/schema/stake"
 	pb "github.com/lbryio/types/v2/go"
 )
 
 func NewChannel() (*c.StakeHelper, *btcec.PrivateKey, error) {
-	claimChannel := new(pb.Claim_Channel)
-	channel := new(pb.Channel)
-	claimChannel.Channel = channel
-
-	pbClaim := new(pb.Claim)
-	pbClaim.Type = claimChannel
-
-	privateKey, err := btcec.NewPrivateKey(btcec.S256())
+	privateKey, _, err := c.NewChannelKey()
 	if err != nil {
-		return nil, nil, errors.Err(err)
+		return nil, nil, err
 	}
-	pubkeyBytes, err := keys.PublicKeyToDER(privateKey.PubKey())
+	helper, err := c.NewChannelClaim(privateKey.PubKey())
 	if err != nil {
-		return nil, nil, errors.Err(err)
+		return nil, nil, err
 	}
 
-	helper := c.StakeHelper{Claim: pbClaim}
-	helper.Version = c.NoSig
-	helper.Claim.GetChannel().PublicKey = pubkeyBytes
 	helper.Claim.Tags = []string{}
 	coverSrc := new(pb.Source)
 	helper.Claim.GetChannel().Cover = coverSrc
@@ -36,5 +24,5 @@ func NewChannel() (*c.StakeHelper, *btcec.PrivateKey, error) {
 	helper.Claim.Thumbnail = thumbnailSrc
 	helper.Claim.Locations = []*pb.Location{}
 
-	return &helper, privateKey, nil
+	return helper, privateKey, nil
 }
@@ -1,6 +1,8 @@
 package lbrycrd
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 
 	"github.com/lbryio/lbry.go/v2/extras/errors"
@@ -9,8 +11,30 @@ import (
 	"github.com/btcsuite/btcutil"
 )
 
-func getClaimSupportPayoutScript(name, claimid string, address btcutil.Address) ([]byte, error) {
-	//OP_SUPPORT_CLAIM <name> <claimid> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+// ClaimNameScript returns the output script of a new claim: the claim name and serialized claim value wrapped in
+// OP_CLAIM_NAME, followed by a script paying to address.
+func ClaimNameScript(name string, value []byte, address btcutil.Address) ([]byte, error) {
+	//OP_CLAIM_NAME <name> <value> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+
+	pkscript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_NOP6).  //OP_CLAIMNAME
+		AddData([]byte(name)).    //<name>
+		AddData(value).           //<value>
+		AddOp(txscript.OP_2DROP). //OP_2DROP
+		AddOp(txscript.OP_DROP).  //OP_DROP
+		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+		Script()
+}
+
+// UpdateClaimScript returns the output script of an update to the claim with the hex claim id claimid, which is
+// wrapped in OP_UPDATE_CLAIM together with the name and the new serialized claim value.
+func UpdateClaimScript(name, claimid string, value []byte, address btcutil.Address) ([]byte, error) {
+	//OP_UPDATE_CLAIM <name> <claimid> <value> OP_2DROP OP_2DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 
 	pkscript, err := txscript.PayToAddrScript(address)
 	if err != nil {
@@ -21,38 +45,53 @@ func getClaimSupportPayoutScript(name, claimid string, address btcutil.Address)
 	if err != nil {
 		return nil, errors.Err(err)
 	}
+	if len(bytes) != claimIDLength {
+		return nil, errors.Err("claim id %s is %d bytes, expected %d", claimid, len(bytes), claimIDLength)
+	}
 
 	return txscript.NewScriptBuilder().
-		AddOp(txscript.OP_NOP7).  //OP_SUPPORT_CLAIM
+		AddOp(txscript.OP_NOP8).  //OP_UPDATE_CLAIM
 		AddData([]byte(name)).    //<name>
 		AddData(rev(bytes)).      //<claimid>
+		AddData(value).           //<value>
+		AddOp(txscript.OP_2DROP). //OP_2DROP
 		AddOp(txscript.OP_2DROP). //OP_2DROP
-		AddOp(txscript.OP_DROP).  //OP_DROP
 		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 		Script()
-
 }
 
-func getClaimNamePayoutScript(name string, value []byte, address btcutil.Address) ([]byte, error) {
-	//OP_CLAIM_NAME <name> <value> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+// SupportClaimScript returns the output script of a support for the claim with the hex claim id claimid, which is
+// wrapped in OP_SUPPORT_CLAIM together with the name.
+func SupportClaimScript(name, claimid string, address btcutil.Address) ([]byte, error) {
+	//OP_SUPPORT_CLAIM <name> <claimid> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 
 	pkscript, err := txscript.PayToAddrScript(address)
 	if err != nil {
 		return nil, errors.Err(err)
 	}
 
+	bytes, err := hex.DecodeString(claimid)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	if len(bytes) != claimIDLength {
+		return nil, errors.Err("claim id %s is %d bytes, expected %d", claimid, len(bytes), claimIDLength)
+	}
+
 	return txscript.NewScriptBuilder().
-		AddOp(txscript.OP_NOP6).  //OP_CLAIMNAME
+		AddOp(txscript.OP_NOP7).  //OP_SUPPORT_CLAIM
 		AddData([]byte(name)).    //<name>
-		AddData(value).           //<value>
+		AddData(rev(bytes)).      //<claimid>
 		AddOp(txscript.OP_2DROP). //OP_2DROP
 		AddOp(txscript.OP_DROP).  //OP_DROP
 		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 		Script()
 }
 
-func getUpdateClaimPayoutScript(name, claimid string, value []byte, address btcutil.Address) ([]byte, error) {
-	//OP_UPDATE_CLAIM <name> <claimid> <value> OP_2DROP OP_DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
+// SupportClaimWithValueScript returns the output script of a support for the claim with the hex claim id claimid that
+// carries data, such as an emoji. The serialized support value is wrapped in OP_SUPPORT_CLAIM after the claim id.
+func SupportClaimWithValueScript(name, claimid string, value []byte, address btcutil.Address) ([]byte, error) {
+	//OP_SUPPORT_CLAIM <name> <claimid> <value> OP_2DROP OP_2DROP OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 
 	pkscript, err := txscript.PayToAddrScript(address)
 	if err != nil {
@@ -63,14 +102,146 @@ func getUpdateClaimPayoutScript(name, claimid string, value []byte, address btcu
 	if err != nil {
 		return nil, errors.Err(err)
 	}
+	if len(bytes) != claimIDLength {
+		return nil, errors.Err("claim id %s is %d bytes, expected %d", claimid, len(bytes), claimIDLength)
+	}
 
 	return txscript.NewScriptBuilder().
-		AddOp(txscript.OP_NOP8).  //OP_UPDATE_CLAIM
+		AddOp(txscript.OP_NOP7).  //OP_SUPPORT_CLAIM
 		AddData([]byte(name)).    //<name>
 		AddData(rev(bytes)).      //<claimid>
 		AddData(value).           //<value>
 		AddOp(txscript.OP_2DROP). //OP_2DROP
-		AddOp(txscript.OP_DROP).  //OP_DROP
+		AddOp(txscript.OP_2DROP). //OP_2DROP
 		AddOps(pkscript).         //OP_DUP OP_HASH160 <address> OP_EQUALVERIFY OP_CHECKSIG
 		Script()
 }
+
+const claimIDLength = 20
+
+// ClaimScript is what ParseClaimScript finds in a claimtrie output script.
+type ClaimScript struct {
+	Type ScriptType
+	Name string
+	// ClaimID is the hex claim id of an update or support, as the daemon shows it. It is empty for a new claim.
+	ClaimID string
+	// Value is the serialized claim value of a new claim or an update, and of a support if it has one.
+	Value []byte
+	// PayScript is the rest of the script, which pays out the output, e.g. OP_DUP OP_HASH160 <address> OP_EQUALVERIFY
+	// OP_CHECKSIG.
+	PayScript []byte
+}
+
+// ParseClaimScript takes apart the output script of a claim, an update or a support. It is the inverse of
+// ClaimNameScript, UpdateClaimScript, SupportClaimScript and SupportClaimWithValueScript.
+func ParseClaimScript(script []byte) (*ClaimScript, error) {
+	if len(script) == 0 {
+		return nil, errors.Err("script is empty")
+	}
+
+	var claimScript ClaimScript
+	var ok bool
+	claimScript.Type, ok = claimScriptType(script)
+	if !ok {
+		return nil, errors.Err("script does not start with a claim opcode")
+	}
+	pushes := 2 //OP_CLAIM_NAME <name> <value>, OP_SUPPORT_CLAIM <name> <claimid> [<value>]
+	if claimScript.Type == ClaimUpdate {
+		pushes = 3 //OP_UPDATE_CLAIM <name> <claimid> <value>
+	}
+
+	var data [][]byte
+	offset := 1
+	for offset < len(script) && len(data) < 3 {
+		push, next, err := readPush(script, offset)
+		if err != nil {
+			return nil, err
+		}
+		if push == nil {
+			break
+		}
+		data = append(data, push)
+		offset = next
+	}
+	if claimScript.Type == ClaimSupport && len(data) == 3 {
+		pushes = 3
+	}
+	if len(data) != pushes {
+		return nil, errors.Err("claim script has %d data pushes, expected %d", len(data), pushes)
+	}
+
+	// two pushes are followed by OP_2DROP OP_DROP, three by OP_2DROP OP_2DROP
+	drops := []byte{txscript.OP_2DROP, txscript.OP_DROP}
+	if pushes == 3 {
+		drops = []byte{txscript.OP_2DROP, txscript.OP_2DROP}
+	}
+	if !bytes.HasPrefix(script[offset:], drops) {
+		return nil, errors.Err("claim script does not drop its %d data pushes", pushes)
+	}
+	offset += len(drops)
+
+	claimScript.Name = string(data[0])
+	if claimScript.Type == ClaimName {
+		claimScript.Value = data[1]
+	} else {
+		if len(data[1]) != claimIDLength {
+			return nil, errors.Err("claim id is %d bytes, expected %d", len(data[1]), claimIDLength)
+		}
+		claimScript.ClaimID = hex.EncodeToString(rev(data[1]))
+		if len(data) == 3 {
+			claimScript.Value = data[2]
+		}
+	}
+	claimScript.PayScript = script[offset:]
+	return &claimScript, nil
+}
+
+// claimScriptType returns the kind of claimtrie script that starts with the opcode at the start of script, or false
+// if it doesn't start with OP_CLAIM_NAME, OP_UPDATE_CLAIM or OP_SUPPORT_CLAIM.
+func claimScriptType(script []byte) (ScriptType, bool) {
+	if len(script) == 0 {
+		return 0, false
+	}
+	switch script[0] {
+	case txscript.OP_NOP6: //OP_CLAIM_NAME
+		return ClaimName, true
+	case txscript.OP_NOP8: //OP_UPDATE_CLAIM
+		return ClaimUpdate, true
+	case txscript.OP_NOP7: //OP_SUPPORT_CLAIM
+		return ClaimSupport, true
+	}
+	return 0, false
+}
+
+// readPush reads the data pushed by the opcode at offset and returns it along with the offset of the next opcode. It
+// returns nil data if the opcode is not a push.
+func readPush(script []byte, offset int) ([]byte, int, error) {
+	op := script[offset]
+	offset++
+
+	var length int
+	switch {
+	case op == txscript.OP_0:
+		return []byte{}, offset, nil
+	case op >= txscript.OP_DATA_1 && op <= txscript.OP_DATA_75:
+		length = int(op)
+	case op == txscript.OP_PUSHDATA1 && offset+1 <= len(script):
+		length = int(script[offset])
+		offset++
+	case op == txscript.OP_PUSHDATA2 && offset+2 <= len(script):
+		length = int(binary.LittleEndian.Uint16(script[offset:]))
+		offset += 2
+	case op == txscript.OP_PUSHDATA4 && offset+4 <= len(script):
+		length = int(binary.LittleEndian.Uint32(script[offset:]))
+		offset += 4
+	case op == txscript.OP_PUSHDATA1 || op == txscript.OP_PUSHDATA2 || op == txscript.OP_PUSHDATA4:
+		return nil, 0, errors.Err("script ends inside the length of a push")
+	default:
+		return nil, offset - 1, nil
+	}
+
+	if length < 0 || offset+length > len(script) {
+		return nil, 0, errors.Err("push of %d bytes runs past the end of the script", length)
+	}
+	return script[offset : offset+length], offset + length, nil
+}
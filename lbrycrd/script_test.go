@@ -0,0 +1,142 @@
+package lbrycrd_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+)
+
+const (
+	scriptAddress = "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"
+	payScript     = "76a914ae2940f56e5bef2bd02049731446cc53c703ced288ac"
+	scriptClaimID = "589bc4845caca70977332025990b2a1807732b44"
+)
+
+var claimScriptTests = []struct {
+	script    string
+	claimType lbrycrd.ScriptType
+	claimID   string
+	value     string
+}{
+	// OP_CLAIM_NAME "test" <value> OP_2DROP OP_DROP
+	{"b5" + "0474657374" + "0401020304" + "6d75" + payScript, lbrycrd.ClaimName, "", "01020304"},
+	// OP_UPDATE_CLAIM "test" <claim id> <value> OP_2DROP OP_2DROP
+	{"b7" + "0474657374" + "14442b7307182a0b992520337709a7ac5c84c49b58" + "0401020304" + "6d6d" + payScript, lbrycrd.ClaimUpdate, scriptClaimID, "01020304"},
+	// OP_SUPPORT_CLAIM "test" <claim id> OP_2DROP OP_DROP
+	{"b6" + "0474657374" + "14442b7307182a0b992520337709a7ac5c84c49b58" + "6d75" + payScript, lbrycrd.ClaimSupport, scriptClaimID, ""},
+	// OP_SUPPORT_CLAIM "test" <claim id> <value> OP_2DROP OP_2DROP
+	{"b6" + "0474657374" + "14442b7307182a0b992520337709a7ac5c84c49b58" + "0401020304" + "6d6d" + payScript, lbrycrd.ClaimSupport, scriptClaimID, "01020304"},
+}
+
+func TestParseClaimScript(t *testing.T) {
+	for _, test := range claimScriptTests {
+		script, _ := hex.DecodeString(test.script)
+		claimScript, err := lbrycrd.ParseClaimScript(script)
+		if err != nil {
+			t.Errorf("%s: %s", test.script, err)
+			continue
+		}
+		if claimScript.Type != test.claimType {
+			t.Errorf("%s: expected type %d, got %d", test.script, test.claimType, claimScript.Type)
+		}
+		if claimScript.Name != "test" {
+			t.Errorf("%s: expected name test, got %s", test.script, claimScript.Name)
+		}
+		if claimScript.ClaimID != test.claimID {
+			t.Errorf("%s: expected claim id %s, got %s", test.script, test.claimID, claimScript.ClaimID)
+		}
+		if hex.EncodeToString(claimScript.Value) != test.value {
+			t.Errorf("%s: expected value %s, got %x", test.script, test.value, claimScript.Value)
+		}
+		if hex.EncodeToString(claimScript.PayScript) != payScript {
+			t.Errorf("%s: expected pay script %s, got %x", test.script, payScript, claimScript.PayScript)
+		}
+	}
+}
+
+func TestClaimScripts(t *testing.T) {
+	address, err := lbrycrd.DecodeAddress(scriptAddress, &lbrycrd.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := []byte{0x01, 0x02, 0x03, 0x04}
+
+	script, err := lbrycrd.ClaimNameScript("test", value, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(script) != claimScriptTests[0].script {
+		t.Errorf("expected claim script %s, got %x", claimScriptTests[0].script, script)
+	}
+
+	script, err = lbrycrd.UpdateClaimScript("test", scriptClaimID, value, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(script) != claimScriptTests[1].script {
+		t.Errorf("expected update script %s, got %x", claimScriptTests[1].script, script)
+	}
+
+	script, err = lbrycrd.SupportClaimScript("test", scriptClaimID, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(script) != claimScriptTests[2].script {
+		t.Errorf("expected support script %s, got %x", claimScriptTests[2].script, script)
+	}
+
+	script, err = lbrycrd.SupportClaimWithValueScript("test", scriptClaimID, value, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(script) != claimScriptTests[3].script {
+		t.Errorf("expected support script with a value %s, got %x", claimScriptTests[3].script, script)
+	}
+
+	_, err = lbrycrd.UpdateClaimScript("test", "589bc4", value, address)
+	if err == nil {
+		t.Error("expected an error for a short claim id")
+	}
+	_, err = lbrycrd.SupportClaimScript("test", "589bc4", address)
+	if err == nil {
+		t.Error("expected an error for a short claim id in a support")
+	}
+	_, err = lbrycrd.SupportClaimWithValueScript("test", "589bc4", value, address)
+	if err == nil {
+		t.Error("expected an error for a short claim id in a support with a value")
+	}
+
+	// a value too long for a single byte push
+	long := bytes.Repeat([]byte{0xaa}, 300)
+	script, err = lbrycrd.UpdateClaimScript("test", scriptClaimID, long, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimScript, err := lbrycrd.ParseClaimScript(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(claimScript.Value, long) || claimScript.ClaimID != scriptClaimID {
+		t.Errorf("round trip of an update with a %d byte value failed", len(long))
+	}
+}
+
+func TestParseInvalidClaimScript(t *testing.T) {
+	tests := []string{
+		"",
+		payScript,
+		"b5" + "0474657374" + "6d75" + payScript,
+		"b5" + "0474657374" + "0401020304" + "6d6d" + payScript,
+		"b5" + "0474657374" + "0801020304",
+		"b7" + "0474657374" + "03442b73" + "0401020304" + "6d6d" + payScript,
+		"b5" + "4d01",
+	}
+	for _, test := range tests {
+		script, _ := hex.DecodeString(test)
+		if _, err := lbrycrd.ParseClaimScript(script); err == nil {
+			t.Errorf("%s: expected an error", test)
+		}
+	}
+}
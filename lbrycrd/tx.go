@@ -0,0 +1,85 @@
+package lbrycrd
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	c "github.com/lbryio/lbry.go/v2/schema/stake"
+	pb "github.com/lbryio/types/v2/go"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// TxClaim is a claim, update or support made by an output of a transaction.
+type TxClaim struct {
+	Name      string
+	Operation ScriptType
+	// ClaimID is the id of the claim the output creates, updates or supports.
+	ClaimID string
+	Nout    int
+	// Amount is the amount of the output in dewies.
+	Amount int64
+	// Value is the serialized claim value, or the value of a support if it has one.
+	Value []byte
+	// Claim is the decoded claim of a claim or update output, nil if Err is set or the output is a support.
+	Claim *pb.Claim
+	// Err is why the output script or its claim value could not be parsed. If it is the script, only Operation, Nout
+	// and Amount are set.
+	Err error
+}
+
+// ParseClaimsFromTx returns the claims, updates and supports made by the outputs of a hex encoded raw transaction, as
+// getrawtransaction returns it, in the order of the outputs. Outputs that don't start with a claim opcode are skipped. A
+// malformed claim script or a claim value that can't be decoded is reported in TxClaim.Err instead of failing the
+// whole transaction. Claim values are decoded as mainnet claims.
+func ParseClaimsFromTx(rawTxHex string) ([]TxClaim, error) {
+	raw, err := hex.DecodeString(rawTxHex)
+	if err != nil {
+		return nil, errors.Prefix("raw transaction is not hex", err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	err = tx.Deserialize(bytes.NewReader(raw))
+	if err != nil {
+		return nil, errors.Prefix("could not deserialize transaction", err)
+	}
+	txid := tx.TxHash().String()
+
+	var claims []TxClaim
+	for nout, out := range tx.TxOut {
+		operation, ok := claimScriptType(out.PkScript)
+		if !ok {
+			continue
+		}
+		claimScript, err := ParseClaimScript(out.PkScript)
+		if err != nil {
+			claims = append(claims, TxClaim{Operation: operation, Nout: nout, Amount: out.Value, Err: err})
+			continue
+		}
+
+		txClaim := TxClaim{
+			Name:      claimScript.Name,
+			Operation: claimScript.Type,
+			ClaimID:   claimScript.ClaimID,
+			Nout:      nout,
+			Amount:    out.Value,
+			Value:     claimScript.Value,
+		}
+		if claimScript.Type == ClaimName {
+			txClaim.ClaimID, err = ClaimIDFromOutpoint(txid, nout)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if claimScript.Type != ClaimSupport {
+			helper, err := c.DecodeClaimBytes(claimScript.Value, "lbrycrd_main")
+			if err != nil {
+				txClaim.Err = err
+			} else {
+				txClaim.Claim = helper.Claim
+			}
+		}
+		claims = append(claims, txClaim)
+	}
+	return claims, nil
+}
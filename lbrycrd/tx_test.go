@@ -0,0 +1,112 @@
+package lbrycrd_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/lbrycrd"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestParseClaimsFromTx(t *testing.T) {
+	address, err := lbrycrd.DecodeAddress(scriptAddress, &lbrycrd.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, err := lbrycrd.NewStreamClaim("title", "description")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := claim.CompileValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	claimScript, err := lbrycrd.ClaimNameScript("first", value, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.AddTxOut(wire.NewTxOut(100000000, claimScript))
+	tx.AddTxOut(wire.NewTxOut(5000000, payScript))
+	updateScript, err := lbrycrd.UpdateClaimScript("second", scriptClaimID, value, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.AddTxOut(wire.NewTxOut(2000000, updateScript))
+	supportScript, _ := hex.DecodeString(claimScriptTests[2].script)
+	tx.AddTxOut(wire.NewTxOut(300000, supportScript))
+	malformedScript, err := lbrycrd.ClaimNameScript("malformed", []byte("not a claim"), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.AddTxOut(wire.NewTxOut(40000, malformedScript))
+	// OP_CLAIM_NAME "test" with a push that runs past the end of the script
+	truncatedScript, _ := hex.DecodeString("b5" + "0474657374" + "0801020304")
+	tx.AddTxOut(wire.NewTxOut(5000, truncatedScript))
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	claims, err := lbrycrd.ParseClaimsFromTx(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstID, err := lbrycrd.ClaimIDFromOutpoint(tx.TxHash().String(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		name      string
+		operation lbrycrd.ScriptType
+		claimID   string
+		nout      int
+		amount    int64
+		decoded   bool
+	}{
+		{"first", lbrycrd.ClaimName, firstID, 0, 100000000, true},
+		{"second", lbrycrd.ClaimUpdate, scriptClaimID, 2, 2000000, true},
+		{"test", lbrycrd.ClaimSupport, scriptClaimID, 3, 300000, false},
+		{"malformed", lbrycrd.ClaimName, "", 4, 40000, false},
+		{"", lbrycrd.ClaimName, "", 5, 5000, false},
+	}
+	if len(claims) != len(expected) {
+		t.Fatalf("expected %d claims, got %d", len(expected), len(claims))
+	}
+	for i, e := range expected {
+		got := claims[i]
+		if got.Name != e.name || got.Operation != e.operation || got.Nout != e.nout || got.Amount != e.amount {
+			t.Errorf("output %d: expected %s %d at %d for %d, got %s %d at %d for %d", i, e.name, e.operation, e.nout, e.amount, got.Name, got.Operation, got.Nout, got.Amount)
+		}
+		if e.claimID != "" && got.ClaimID != e.claimID {
+			t.Errorf("output %d: expected claim id %s, got %s", i, e.claimID, got.ClaimID)
+		}
+		if e.decoded && (got.Err != nil || got.Claim.GetTitle() != "title") {
+			t.Errorf("output %d: expected the claim to decode, got %v", i, got.Err)
+		}
+	}
+	if claims[3].Err == nil || claims[3].Claim != nil {
+		t.Error("expected a decoding error for the malformed claim")
+	}
+	if claims[4].Err == nil || claims[4].Claim != nil {
+		t.Error("expected a script error for the truncated claim script")
+	}
+
+	if _, err := lbrycrd.ParseClaimsFromTx("zz"); err == nil {
+		t.Error("expected an error for a transaction that is not hex")
+	}
+	if _, err := lbrycrd.ParseClaimsFromTx("0100"); err == nil {
+		t.Error("expected an error for a truncated transaction")
+	}
+}
@@ -0,0 +1,131 @@
+package address
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/schema/address/base58"
+)
+
+// Network is the lbrycrd network an address belongs to.
+type Network int
+
+const (
+	Mainnet Network = iota
+	// Testnet addresses are also used on regtest, which has the same prefixes.
+	Testnet
+)
+
+func (n Network) String() string {
+	switch n {
+	case Mainnet:
+		return "mainnet"
+	case Testnet:
+		return "testnet"
+	}
+	return fmt.Sprintf("Network(%d)", int(n))
+}
+
+// NetworkFor returns the network of addresses on the given blockchain.
+func NetworkFor(blockchainName string) (Network, error) {
+	switch blockchainName {
+	case lbrycrdMain:
+		return Mainnet, nil
+	case lbrycrdTestnet, lbrycrdRegtest:
+		return Testnet, nil
+	}
+	return 0, errors.Err("invalid blockchain name")
+}
+
+// Type is what an address pays to.
+type Type int
+
+const (
+	// PubKeyHash addresses (P2PKH) pay to the hash of a public key.
+	PubKeyHash Type = iota
+	// ScriptHash addresses (P2SH) pay to the hash of a script.
+	ScriptHash
+)
+
+func (t Type) String() string {
+	switch t {
+	case PubKeyHash:
+		return "pubkey hash"
+	case ScriptHash:
+		return "script hash"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// Address is a decoded address: the network and type its prefix stands for and the hash160 it pays to.
+type Address struct {
+	Network Network
+	Type    Type
+	Hash160 [pubkeyLength]byte
+}
+
+var prefixTypes = map[byte]struct {
+	Network Network
+	Type    Type
+}{
+	lbrycrdMainPubkeyPrefix:    {Mainnet, PubKeyHash},
+	lbrycrdMainScriptPrefix:    {Mainnet, ScriptHash},
+	lbrycrdTestnetPubkeyPrefix: {Testnet, PubKeyHash},
+	lbrycrdTestnetScriptPrefix: {Testnet, ScriptHash},
+}
+
+// Decode decodes a base58check address, verifying its checksum, and tells from its prefix which network it is for
+// and what type of address it is.
+func Decode(address string) (Address, error) {
+	decoded, err := base58.DecodeBase58(address, addressLength)
+	if err != nil {
+		return Address{}, errors.Err("failed to decode")
+	}
+	var buf [addressLength]byte
+	copy(buf[:], decoded)
+	return FromBytes(buf)
+}
+
+// FromBytes reads an address in the raw form claims store fee addresses in: the prefix, hash160 and checksum.
+func FromBytes(address [addressLength]byte) (Address, error) {
+	if !ChecksumIsValid(address) {
+		return Address{}, errors.Err("invalid address checksum")
+	}
+	prefixType, ok := prefixTypes[address[0]]
+	if !ok {
+		return Address{}, errors.Err("invalid prefix")
+	}
+	a := Address{Network: prefixType.Network, Type: prefixType.Type}
+	copy(a.Hash160[:], address[prefixLength:prefixLength+pubkeyLength])
+	return a, nil
+}
+
+// Encode returns the base58check form of the address.
+func Encode(a Address) (string, error) {
+	buf, err := a.Bytes()
+	if err != nil {
+		return "", err
+	}
+	return base58.EncodeBase58(buf[:]), nil
+}
+
+// Bytes returns the address in the raw form claims store fee addresses in: the prefix, hash160 and checksum.
+func (a Address) Bytes() ([addressLength]byte, error) {
+	var buf [addressLength]byte
+	found := false
+	for prefix, prefixType := range prefixTypes {
+		if prefixType.Network == a.Network && prefixType.Type == a.Type {
+			buf[0] = prefix
+			found = true
+		}
+	}
+	if !found {
+		return buf, errors.Err("no address prefix for %s %s", a.Network, a.Type)
+	}
+	copy(buf[prefixLength:], a.Hash160[:])
+	checksum := sha256.Sum256(buf[:prefixLength+pubkeyLength])
+	checksum = sha256.Sum256(checksum[:])
+	copy(buf[prefixLength+pubkeyLength:], checksum[:checksumLength])
+	return buf, nil
+}
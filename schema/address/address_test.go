@@ -27,3 +27,76 @@ func TestEncodeAddressLBRYCrdMain(t *testing.T) {
 		t.Error("Mismatch")
 	}
 }
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		Address string
+		Network Network
+		Type    Type
+	}{
+		{"bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6", Mainnet, PubKeyHash},
+		{"bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN", Mainnet, PubKeyHash},
+		{"r6stj4JjLFtCmynDqDVjmkDXS9zfJa56qc", Mainnet, ScriptHash},
+		{"mgAFts2ZXGnZnDFGZbqESNDsWcAHVZMVjM", Testnet, PubKeyHash},
+		{"2MstXb6N3scB3J46deG9Kf2Mk1V4Tsu2egZ", Testnet, ScriptHash},
+	}
+	for _, test := range tests {
+		decoded, err := Decode(test.Address)
+		if err != nil {
+			t.Errorf("%s: %v", test.Address, err)
+			continue
+		}
+		if decoded.Network != test.Network || decoded.Type != test.Type {
+			t.Errorf("%s: got %s %s, expected %s %s", test.Address, decoded.Network, decoded.Type, test.Network, test.Type)
+		}
+		encoded, err := Encode(decoded)
+		if err != nil {
+			t.Error(err)
+		}
+		if encoded != test.Address {
+			t.Errorf("%s: encoded back as %s", test.Address, encoded)
+		}
+	}
+
+	decoded, err := Decode("bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash160 := [20]byte{174, 41, 64, 245, 110, 91, 239, 43, 208, 32, 73, 115, 20, 70, 204, 83, 199, 3, 206, 210}
+	if decoded.Hash160 != hash160 {
+		t.Error("Mismatch")
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := map[string]string{
+		"bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP7": "invalid address checksum",
+		"1BoatSLRHtKNngkdXEeobR76b53LETtpyT": "invalid prefix",
+		"bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP0": "failed to decode",
+		"":                                   "invalid address checksum",
+	}
+	for address, message := range tests {
+		_, err := Decode(address)
+		if err == nil || err.Error() != message {
+			t.Errorf("%s: expected '%s', got %v", address, message, err)
+		}
+	}
+
+	_, err := Encode(Address{Network: Network(5)})
+	if err == nil {
+		t.Error("expected an error for an unknown network")
+	}
+}
+
+func TestNetworkFor(t *testing.T) {
+	tests := map[string]Network{"lbrycrd_main": Mainnet, "lbrycrd_testnet": Testnet, "lbrycrd_regtest": Testnet}
+	for blockchainName, network := range tests {
+		result, err := NetworkFor(blockchainName)
+		if err != nil || result != network {
+			t.Errorf("%s: got %s, %v", blockchainName, result, err)
+		}
+	}
+	if _, err := NetworkFor("not_a_chain"); err == nil {
+		t.Error("expected an error for an unknown blockchain")
+	}
+}
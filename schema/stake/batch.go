@@ -0,0 +1,165 @@
+package stake
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// RawClaim is a claim value waiting to be migrated, along with an id to match it to its Result.
+type RawClaim struct {
+	ID    string
+	Value []byte
+}
+
+// Result is the outcome of migrating a RawClaim. Either Claim or Err is set.
+type Result struct {
+	ID       string
+	Version  ClaimVersion
+	Claim    *pb.Claim
+	Warnings []string
+	Err      error
+}
+
+// MigrationStats counts the claims a BatchMigrator has migrated, by the version they were in and, for the ones that
+// failed, by the kind of error.
+type MigrationStats struct {
+	Migrated  int
+	Failed    int
+	ByVersion map[ClaimVersion]int
+	ByError   map[string]int
+}
+
+// BatchMigrator migrates large numbers of claims concurrently and keeps count of the results.
+type BatchMigrator struct {
+	opts MigrationOptions
+
+	mu    sync.Mutex
+	stats MigrationStats
+}
+
+// NewBatchMigrator returns a BatchMigrator that migrates json claims with opts.
+func NewBatchMigrator(opts MigrationOptions) *BatchMigrator {
+	return &BatchMigrator{opts: opts, stats: MigrationStats{
+		ByVersion: make(map[ClaimVersion]int),
+		ByError:   make(map[string]int),
+	}}
+}
+
+// MigrateAll migrates the claims read from input with the given number of workers, and sends a Result for each one.
+// Json claims of any version are migrated, hex encoded protobuf claims are decoded as they are. Results come out in
+// the order they finish, not the order they went in, so use Result.ID to match them up. The results channel is closed
+// once input is closed and every claim read from it is done, or once ctx is canceled. At most one claim per worker is
+// held in memory, so the results have to be read for the migration to go on.
+func (m *BatchMigrator) MigrateAll(ctx context.Context, input <-chan RawClaim, workers int) (<-chan Result, error) {
+	if workers < 1 {
+		return nil, errors.Err("need at least one worker, got %d", workers)
+	}
+	if input == nil {
+		return nil, errors.Err("input is nil")
+	}
+
+	results := make(chan Result, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var raw RawClaim
+				var ok bool
+				select {
+				case <-ctx.Done():
+					return
+				case raw, ok = <-input:
+					if !ok {
+						return
+					}
+				}
+
+				result := m.migrate(raw)
+				select {
+				case <-ctx.Done():
+					return
+				case results <- result:
+					m.count(result)
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results, nil
+}
+
+// Stats returns the counts of the results sent so far. Results dropped because ctx was canceled aren't counted. Once
+// the results channel is closed the counts are final.
+func (m *BatchMigrator) Stats() MigrationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := MigrationStats{
+		Migrated:  m.stats.Migrated,
+		Failed:    m.stats.Failed,
+		ByVersion: make(map[ClaimVersion]int, len(m.stats.ByVersion)),
+		ByError:   make(map[string]int, len(m.stats.ByError)),
+	}
+	for version, count := range m.stats.ByVersion {
+		stats.ByVersion[version] = count
+	}
+	for kind, count := range m.stats.ByError {
+		stats.ByError[kind] = count
+	}
+	return stats
+}
+
+func (m *BatchMigrator) migrate(raw RawClaim) Result {
+	result := Result{ID: raw.ID}
+	result.Version, _, result.Err = detectVersion(raw.Value)
+	if result.Err == nil {
+		if result.Version == VersionProtobufHex {
+			result.Claim, result.Err = decodeProtobufHex(raw.Value, m.opts.blockchainName())
+		} else {
+			result.Claim, result.Warnings, result.Err = MigrateFromJSONWithWarnings(raw.Value, m.opts)
+		}
+	}
+	return result
+}
+
+func (m *BatchMigrator) count(result Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats.Add(result.Version, result.Err)
+}
+
+func decodeProtobufHex(value []byte, blockchainName string) (*pb.Claim, error) {
+	decoded, err := hex.DecodeString(string(value))
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	helper, err := DecodeClaimProtoBytes(decoded, blockchainName)
+	if err != nil {
+		return nil, err
+	}
+	return helper.Claim, nil
+}
+
+// errorKind names the kind of a migration error for MigrationStats.
+func errorKind(err error) string {
+	switch errors.Unwrap(err).(type) {
+	case ErrUnknownClaimVersion:
+		return "unknown version"
+	case ErrUnknownLanguage:
+		return "unknown language"
+	case ErrUnknownCurrency:
+		return "unknown currency"
+	case ValidationErrors:
+		return "invalid claim"
+	}
+	return "other"
+}
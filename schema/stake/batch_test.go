@@ -0,0 +1,152 @@
+package stake
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+// batchFixtures covers every version MigrateAll accepts and the kinds of errors it counts.
+func batchFixtures(t *testing.T) map[string]struct {
+	Value   []byte
+	Version ClaimVersion
+	Error   string
+} {
+	stream := &StakeHelper{Claim: validStreamClaim(t), Version: NoSig}
+	value, err := stream.CompileValue()
+	assert.NilError(t, err)
+
+	return map[string]struct {
+		Value   []byte
+		Version ClaimVersion
+		Error   string
+	}{
+		"v1":       {[]byte(`{"title": "v1", "language": "en", "content-type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), VersionV1, ""},
+		"v2":       {[]byte(`{"ver": "0.0.2", "title": "v2", "language": "en", "content-type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), VersionV2, ""},
+		"v3":       {[]byte(`{"ver": "0.0.3", "title": "v3", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), VersionV3, ""},
+		"protobuf": {[]byte(hex.EncodeToString(value)), VersionProtobufHex, ""},
+		"version":  {[]byte(`{"ver": "0.0.9", "title": "future"}`), VersionUnknown, "unknown version"},
+		"language": {[]byte(`{"ver": "0.0.3", "title": "typo", "language": "engrish", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), VersionV3, "unknown language"},
+		"currency": {[]byte(`{"ver": "0.0.3", "title": "euros", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"EUR": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`), VersionV3, "unknown currency"},
+		"invalid":  {[]byte(`{"ver": "0.0.3", "title": "", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), VersionV3, "invalid claim"},
+		"garbage":  {[]byte(`not a claim`), VersionUnknown, "other"},
+	}
+}
+
+func TestMigrateAll(t *testing.T) {
+	fixtures := batchFixtures(t)
+	input := make(chan RawClaim)
+	go func() {
+		for id, fixture := range fixtures {
+			input <- RawClaim{ID: id, Value: fixture.Value}
+		}
+		close(input)
+	}()
+
+	migrator := NewBatchMigrator(MigrationOptions{Strict: true})
+	results, err := migrator.MigrateAll(context.Background(), input, 4)
+	assert.NilError(t, err)
+
+	seen := make(map[string]bool)
+	for result := range results {
+		fixture, ok := fixtures[result.ID]
+		assert.Assert(t, ok, result.ID)
+		assert.Assert(t, !seen[result.ID], result.ID)
+		seen[result.ID] = true
+
+		assert.Equal(t, result.Version, fixture.Version, result.ID)
+		if fixture.Error == "" {
+			assert.NilError(t, result.Err, result.ID)
+			assert.Assert(t, result.Claim.GetStream() != nil, result.ID)
+		} else {
+			assert.Assert(t, result.Err != nil, result.ID)
+			assert.Equal(t, errorKind(result.Err), fixture.Error, result.ID)
+		}
+	}
+	assert.Equal(t, len(seen), len(fixtures))
+
+	stats := migrator.Stats()
+	assert.Equal(t, stats.Migrated, 4)
+	assert.Equal(t, stats.Failed, 5)
+	assert.DeepEqual(t, stats.ByVersion, map[ClaimVersion]int{VersionV1: 1, VersionV2: 1, VersionV3: 4, VersionProtobufHex: 1, VersionUnknown: 2})
+	assert.DeepEqual(t, stats.ByError, map[string]int{"unknown version": 1, "unknown language": 1, "unknown currency": 1, "invalid claim": 1, "other": 1})
+}
+
+func TestMigrateAllCancel(t *testing.T) {
+	input := make(chan RawClaim)
+	ctx, cancel := context.WithCancel(context.Background())
+	migrator := NewBatchMigrator(MigrationOptions{})
+	results, err := migrator.MigrateAll(ctx, input, 2)
+	assert.NilError(t, err)
+
+	input <- RawClaim{ID: "v1", Value: batchFixtures(t)["v1"].Value}
+	result := <-results
+	assert.NilError(t, result.Err)
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		assert.Assert(t, !ok, "expected no more results after cancel")
+	case <-time.After(5 * time.Second):
+		t.Fatal("results were not closed after cancel")
+	}
+	assert.Equal(t, migrator.Stats().Migrated, 1)
+}
+
+func TestMigrateAllCancelDropsUnsentResults(t *testing.T) {
+	input := make(chan RawClaim)
+	ctx, cancel := context.WithCancel(context.Background())
+	migrator := NewBatchMigrator(MigrationOptions{})
+	results, err := migrator.MigrateAll(ctx, input, 1)
+	assert.NilError(t, err)
+
+	// the first result fills the results buffer, so the second one can't be sent until the first is read
+	input <- RawClaim{ID: "first", Value: batchFixtures(t)["v1"].Value}
+	input <- RawClaim{ID: "second", Value: batchFixtures(t)["v2"].Value}
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	received := 0
+	for range results {
+		received++
+	}
+	stats := migrator.Stats()
+	assert.Equal(t, stats.Migrated+stats.Failed, received)
+}
+
+func TestMigrateAllErrors(t *testing.T) {
+	migrator := NewBatchMigrator(MigrationOptions{})
+	_, err := migrator.MigrateAll(context.Background(), make(chan RawClaim), 0)
+	assert.ErrorContains(t, err, "at least one worker")
+	_, err = migrator.MigrateAll(context.Background(), nil, 1)
+	assert.ErrorContains(t, err, "input is nil")
+}
+
+func BenchmarkMigrateAll(b *testing.B) {
+	value := []byte(`{"ver": "0.0.3", "title": "v3", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			input := make(chan RawClaim, workers)
+			go func() {
+				for i := 0; i < b.N; i++ {
+					input <- RawClaim{ID: fmt.Sprint(i), Value: value}
+				}
+				close(input)
+			}()
+
+			results, err := NewBatchMigrator(MigrationOptions{}).MigrateAll(context.Background(), input, workers)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for result := range results {
+				if result.Err != nil {
+					b.Fatal(result.Err)
+				}
+			}
+		})
+	}
+}
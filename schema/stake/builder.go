@@ -0,0 +1,324 @@
+package stake
+
+import (
+	"fmt"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// ClaimBuilder constructs stream claims. Setters can be chained and problems with the values are reported by Build,
+// so a claim is either complete and valid or not returned at all.
+//
+//	claim, err := stake.NewClaimBuilder().
+//		Title("title").
+//		SDHash(sdHash).
+//		ContentType("video/mp4").
+//		Fee(pb.Fee_LBC, 1, address).
+//		Build()
+type ClaimBuilder struct {
+	title       string
+	author      string
+	description string
+	languages   []pb.Language_Language
+	license     string
+	licenseURL  string
+	thumbnail   string
+	nsfw        bool
+	rating      ContentRating
+	fee         *Fee
+	sdHash      string
+	contentType string
+	ipfsCID     string
+	audio       *pb.Audio
+	video       *pb.Video
+	document    bool
+	metadata    string
+
+	opts      MigrationOptions
+	migrating bool
+	err       error
+
+	limits   *FieldLimits
+	truncate bool
+}
+
+// NewClaimBuilder returns a builder for a stream claim whose fee addresses are checked against lbrycrd_main.
+func NewClaimBuilder() *ClaimBuilder {
+	return &ClaimBuilder{}
+}
+
+// newMigrationBuilder returns a builder that applies the leniency of opts to the claim being migrated.
+func newMigrationBuilder(opts MigrationOptions) *ClaimBuilder {
+	return &ClaimBuilder{opts: opts, migrating: true}
+}
+
+func (b *ClaimBuilder) Title(title string) *ClaimBuilder {
+	b.title = title
+	return b
+}
+
+func (b *ClaimBuilder) Author(author string) *ClaimBuilder {
+	b.author = author
+	return b
+}
+
+func (b *ClaimBuilder) Description(description string) *ClaimBuilder {
+	b.description = description
+	return b
+}
+
+// Language defaults to UNKNOWN_LANGUAGE, use ResolveLanguage to get one from a free form string.
+func (b *ClaimBuilder) Language(language pb.Language_Language) *ClaimBuilder {
+	return b.Languages(language)
+}
+
+// Languages sets the primary language of the claim and any others it is published in, e.g. with localized titles.
+// Use ResolveLanguages to get them from free form strings. Repeated and unknown languages are left out, so if the
+// primary language is unknown the first additional one takes its place. The primary language is the one the V3 json
+// of the claim carries.
+func (b *ClaimBuilder) Languages(primary pb.Language_Language, additional ...pb.Language_Language) *ClaimBuilder {
+	b.languages = append([]pb.Language_Language{primary}, additional...)
+	return b
+}
+
+// License sets the license name and, if it has one, the url of the license text. If the url is empty and
+// LookupLicenseURL knows the license, its url is filled in. A url that isn't the one of a known license is an error.
+// Migrated claims keep the url they were published with, and one filled in is reported as a warning.
+func (b *ClaimBuilder) License(license string, licenseURL string) *ClaimBuilder {
+	b.license = license
+	b.licenseURL = licenseURL
+	return b
+}
+
+// Thumbnail sets the url of the claim's thumbnail. The claim has no thumbnail if it is empty, otherwise the url is
+// normalized with NormalizeThumbnailURL.
+func (b *ClaimBuilder) Thumbnail(url string) *ClaimBuilder {
+	b.thumbnail = url
+	return b
+}
+
+func (b *ClaimBuilder) NSFW(nsfw bool) *ClaimBuilder {
+	b.nsfw = nsfw
+	return b
+}
+
+// Rating tags the claim with a content rating, use NormalizeContentRating to get one from a free form string.
+func (b *ClaimBuilder) Rating(rating ContentRating) *ClaimBuilder {
+	normalized, ok := NormalizeContentRating(string(rating))
+	if !ok {
+		b.setErr(errors.Err("unknown content rating '%s'", rating))
+		return b
+	}
+	b.rating = normalized
+	return b
+}
+
+// Fee charges amount in currency to address, which must be a base58check encoded address on the builder's blockchain.
+func (b *ClaimBuilder) Fee(currency pb.Fee_Currency, amount float32, address string) *ClaimBuilder {
+	code, ok := currencyCode(currency)
+	if !ok {
+		b.setErr(errors.Err("unsupported fee currency %s", currency))
+		return b
+	}
+	b.fee = &Fee{code: &FeeInfo{Amount: amount, Address: address}}
+	return b
+}
+
+// jsonFee sets the fee from a json claim as is, so Build can reject the ones that are ambiguous.
+func (b *ClaimBuilder) jsonFee(fee *Fee) *ClaimBuilder {
+	b.fee = fee
+	return b
+}
+
+// SDHash sets the hex encoded sd hash of the stream.
+func (b *ClaimBuilder) SDHash(sdHash string) *ClaimBuilder {
+	b.sdHash = sdHash
+	return b
+}
+
+// ipfsSource sets the IPFS CID of a json claim's content, which is added to its source by setIPFSSource.
+func (b *ClaimBuilder) ipfsSource(cid string) *ClaimBuilder {
+	b.ipfsCID = cid
+	return b
+}
+
+// ContentType sets the media type of the stream. It is normalized with NormalizeContentType, so it has to be one of
+// SupportedContentTypes or a mistake ContentTypeNormalizations knows how to fix.
+func (b *ClaimBuilder) ContentType(contentType string) *ClaimBuilder {
+	b.contentType = contentType
+	return b
+}
+
+// Audio attaches the duration, in seconds, of an audio stream. The content type has to be an audio/* type.
+func (b *ClaimBuilder) Audio(duration uint32) *ClaimBuilder {
+	b.setMetadata("audio")
+	b.audio = &pb.Audio{Duration: duration}
+	return b
+}
+
+// Document marks the stream as a document such as a pdf or an ebook. The schema has no document metadata, so the
+// claim carries none, but the content type has to be text/* or one of the document formats.
+func (b *ClaimBuilder) Document() *ClaimBuilder {
+	b.setMetadata("document")
+	b.document = true
+	return b
+}
+
+// Video attaches the duration in seconds and the width and height in pixels of a video stream. Any of them can be 0
+// if it isn't known. The content type has to be a video/* type.
+func (b *ClaimBuilder) Video(duration uint32, width uint32, height uint32) *ClaimBuilder {
+	b.setMetadata("video")
+	if duration > maxVideoDuration || width > maxVideoDimension || height > maxVideoDimension {
+		b.setErr(errors.Err("video duration %d, width %d or height %d is too large", duration, width, height))
+		return b
+	}
+	b.video = &pb.Video{Duration: duration, Width: width, Height: height}
+	return b
+}
+
+// setMetadata records which kind of media metadata the stream has, since it can only have one.
+func (b *ClaimBuilder) setMetadata(kind string) {
+	if b.metadata != "" && b.metadata != kind {
+		b.setErr(errors.Err("a stream can't have both %s and %s metadata", b.metadata, kind))
+	}
+	b.metadata = kind
+}
+
+// Limits overrides DefaultFieldLimits for the claim. Build checks the author against these limits instead of
+// MaxAuthorLength, which Validate still uses. Migrated claims are not limited.
+func (b *ClaimBuilder) Limits(limits FieldLimits) *ClaimBuilder {
+	b.limits = &limits
+	return b
+}
+
+// Truncate makes Build cut fields that are over their limit, on a character boundary and with an ellipsis at the end
+// if the limit leaves room for one, instead of failing. BuildWithWarnings reports each field that was cut.
+func (b *ClaimBuilder) Truncate() *ClaimBuilder {
+	b.truncate = true
+	return b
+}
+
+// BlockchainName sets the network fee addresses are checked against.
+func (b *ClaimBuilder) BlockchainName(blockchainName string) *ClaimBuilder {
+	b.opts.BlockchainName = blockchainName
+	return b
+}
+
+func (b *ClaimBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build returns the claim, or the first problem with the values it was given followed by any violations of Validate.
+func (b *ClaimBuilder) Build() (*pb.Claim, error) {
+	claim, _, err := b.BuildWithWarnings()
+	return claim, err
+}
+
+// BuildWithWarnings works like Build, also returning a description of every fix applied to the values it was given,
+// such as a normalized content type or a truncated field.
+func (b *ClaimBuilder) BuildWithWarnings() (*pb.Claim, []string, error) {
+	if b.err != nil {
+		return nil, nil, b.err
+	}
+
+	claim := newStreamClaim()
+	claim.Title = b.title
+	claim.Description = b.description
+	claim.Languages = []*pb.Language{{Language: pb.Language_UNKNOWN_LANGUAGE}}
+	if languages := uniqueLanguages(b.languages); len(languages) > 0 {
+		claim.Languages = make([]*pb.Language, len(languages))
+		for i, language := range languages {
+			claim.Languages[i] = &pb.Language{Language: language}
+		}
+	}
+	var warnings []string
+	if b.thumbnail != "" {
+		thumbnail, fixes, err := normalizeThumbnail(b.thumbnail, b.title, b.migrating && !b.opts.Strict)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, fixes...)
+		claim.Thumbnail = &pb.Source{Url: thumbnail}
+	}
+	if b.nsfw {
+		setMature(claim)
+	}
+	if b.rating != "" {
+		setContentRating(claim, b.rating)
+	}
+
+	stream := claim.GetStream()
+	stream.Author = b.author
+	stream.License = b.license
+	stream.LicenseUrl = b.licenseURL
+	if !b.migrating {
+		limits := DefaultFieldLimits
+		if b.limits != nil {
+			limits = *b.limits
+		}
+		truncated, err := applyFieldLimits(claim, limits, b.truncate)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, truncated...)
+	}
+	if !b.migrating || b.opts.Strict {
+		err := checkLicenseURL(b.license, b.licenseURL)
+		if err != nil {
+			return nil, nil, errors.Prefix(fmt.Sprintf("claim '%s'", b.title), err)
+		}
+	}
+	if licenseURL, ok := LookupLicenseURL(b.license); ok && b.licenseURL == "" {
+		stream.LicenseUrl = licenseURL
+		if b.migrating {
+			warnings = append(warnings, fmt.Sprintf("license url: filled in %s for '%s'", licenseURL, b.license))
+		}
+	}
+
+	err := setFee(b.fee, claim, b.opts.blockchainName())
+	if err != nil {
+		return nil, nil, err
+	}
+	sdHash, fixes, err := decodeSDHash(b.sdHash, b.title, b.opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, fixes...)
+	contentType, fixes, err := normalizeContentType(b.contentType, b.migrating && !b.opts.Strict)
+	if err != nil {
+		return nil, nil, errors.Prefix(fmt.Sprintf("claim '%s'", b.title), err)
+	}
+	warnings = append(warnings, fixes...)
+	stream.Source = &pb.Source{SdHash: sdHash, MediaType: contentType}
+	if b.ipfsCID != "" {
+		err = setIPFSSource(stream.Source, b.ipfsCID)
+		if err != nil {
+			return nil, nil, errors.Prefix(fmt.Sprintf("claim '%s'", b.title), err)
+		}
+	}
+	if b.audio != nil {
+		stream.Type = &pb.Stream_Audio{Audio: b.audio}
+	}
+	if b.video != nil {
+		stream.Type = &pb.Stream_Video{Video: b.video}
+	}
+	if b.document {
+		err = validateDocumentType(contentType)
+		if err != nil {
+			return nil, nil, errors.Err(err)
+		}
+	}
+
+	authorLimit := MaxAuthorLength
+	if b.limits != nil && !b.migrating {
+		authorLimit = b.limits.Author
+	}
+	err = validate(claim, b.opts, authorLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return claim, warnings, nil
+}
@@ -0,0 +1,170 @@
+package stake
+
+import (
+	"encoding/hex"
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestClaimBuilder(t *testing.T) {
+	claim, err := NewClaimBuilder().
+		Title("title").
+		Author("author").
+		Description("description").
+		Language(pb.Language_fr).
+		License("CC-BY", "https://creativecommons.org/licenses/by/4.0/").
+		Thumbnail("https://example.com/thumbnail.jpg").
+		NSFW(true).
+		Fee(pb.Fee_USD, 1.5, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6").
+		SDHash(testSDHash).
+		ContentType("video/mp4").
+		Build()
+	assert.NilError(t, err)
+
+	assert.Equal(t, claim.GetTitle(), "title")
+	assert.Equal(t, claim.GetDescription(), "description")
+	assert.Equal(t, claim.GetLanguages()[0].GetLanguage(), pb.Language_fr)
+	assert.Equal(t, claim.GetThumbnail().GetUrl(), "https://example.com/thumbnail.jpg")
+	assert.DeepEqual(t, claim.GetTags(), []string{"mature"})
+	stream := claim.GetStream()
+	assert.Equal(t, stream.GetAuthor(), "author")
+	assert.Equal(t, stream.GetLicense(), "CC-BY")
+	assert.Equal(t, stream.GetLicenseUrl(), "https://creativecommons.org/licenses/by/4.0/")
+	assert.Equal(t, stream.GetFee().GetCurrency(), pb.Fee_USD)
+	assert.Equal(t, stream.GetFee().GetAmount(), uint64(150))
+	assert.Equal(t, len(stream.GetFee().GetAddress()), addressLength)
+	assert.Equal(t, hex.EncodeToString(stream.GetSource().GetSdHash()), testSDHash)
+	assert.Equal(t, stream.GetSource().GetMediaType(), "video/mp4")
+}
+
+func TestClaimBuilderDefaults(t *testing.T) {
+	claim, err := validBuilder().Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetLanguages()[0].GetLanguage(), pb.Language_UNKNOWN_LANGUAGE)
+	assert.Assert(t, claim.GetThumbnail() == nil)
+	assert.Assert(t, claim.GetTags() == nil)
+	assert.Assert(t, claim.GetStream().GetFee() == nil)
+}
+
+func TestClaimBuilderErrors(t *testing.T) {
+	tests := map[string]*ClaimBuilder{
+		"title is empty":           validBuilder().Title(""),
+		"sd hash is empty":         validBuilder().SDHash(""),
+		"not a valid media type":   validBuilder().ContentType(""),
+		"unsupported fee currency": validBuilder().Fee(pb.Fee_UNKNOWN_CURRENCY, 1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"),
+		"has no address":           validBuilder().Fee(pb.Fee_LBC, 1, ""),
+		"invalid fee address":      validBuilder().Fee(pb.Fee_LBC, 1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6").BlockchainName("lbrycrd_testnet"),
+	}
+	for message, builder := range tests {
+		claim, err := builder.Build()
+		assert.ErrorContains(t, err, message)
+		assert.Assert(t, claim == nil, message)
+	}
+}
+
+func TestClaimBuilderMediaMetadata(t *testing.T) {
+	claim, err := validBuilder().ContentType("audio/mpeg").Audio(3600).Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetAudio().GetDuration(), uint32(3600))
+
+	for _, contentType := range []string{"application/pdf", "text/plain; charset=utf-8", "application/epub+zip"} {
+		claim, err = validBuilder().ContentType(contentType).Document().Build()
+		assert.NilError(t, err, contentType)
+		assert.Assert(t, claim.GetStream().GetType() == nil, contentType)
+	}
+
+	tests := []struct {
+		builder *ClaimBuilder
+		message string
+	}{
+		{validBuilder().ContentType("video/mp4").Audio(60), "audio metadata does not match content type 'video/mp4'"},
+		{validBuilder().ContentType("application/pdf").Audio(60), "audio metadata does not match content type 'application/pdf'"},
+		{validBuilder().ContentType("audio/mpeg").Document(), "content type 'audio/mpeg' is not a document type"},
+		{validBuilder().ContentType("image/png").Document(), "content type 'image/png' is not a document type"},
+		{validBuilder().ContentType("application/pdf").Document().Audio(60), "both document and audio metadata"},
+		{validBuilder().ContentType("audio/mpeg").Audio(60).Document(), "both audio and document metadata"},
+	}
+	for _, test := range tests {
+		claim, err := test.builder.Build()
+		assert.ErrorContains(t, err, test.message)
+		assert.Assert(t, claim == nil, test.message)
+	}
+}
+
+func TestClaimBuilderVideo(t *testing.T) {
+	claim, err := validBuilder().Video(5400, 1920, 1080).Build()
+	assert.NilError(t, err)
+	value, err := (&StakeHelper{Claim: claim, Version: NoSig}).CompileValue()
+	assert.NilError(t, err)
+	decoded, err := DecodeClaimBytes(value, "lbrycrd_main")
+	assert.NilError(t, err)
+	video := decoded.Claim.GetStream().GetVideo()
+	assert.Equal(t, video.GetDuration(), uint32(5400))
+	assert.Equal(t, video.GetWidth(), uint32(1920))
+	assert.Equal(t, video.GetHeight(), uint32(1080))
+
+	claim, err = validBuilder().Build()
+	assert.NilError(t, err)
+	assert.Assert(t, claim.GetStream().GetVideo() == nil)
+	claim, err = validBuilder().Video(60, 0, 0).Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetVideo().GetDuration(), uint32(60))
+
+	tests := []struct {
+		builder *ClaimBuilder
+		message string
+	}{
+		{validBuilder().Video(maxVideoDuration+1, 1920, 1080), "too large"},
+		{validBuilder().Video(60, 1920, 100000), "too large"},
+		{validBuilder().ContentType("audio/mpeg").Video(60, 1920, 1080), "video metadata does not match content type 'audio/mpeg'"},
+		{validBuilder().Audio(60).Video(60, 1920, 1080), "both audio and video metadata"},
+	}
+	for _, test := range tests {
+		claim, err := test.builder.Build()
+		assert.ErrorContains(t, err, test.message)
+		assert.Assert(t, claim == nil, test.message)
+	}
+
+	claim, err = validBuilder().Video(60, 1920, 1080).Build()
+	assert.NilError(t, err)
+	claim.GetStream().GetVideo().Width = 100000
+	claim.GetStream().GetVideo().Duration = maxVideoDuration + 1
+	err = Validate(claim)
+	assert.ErrorContains(t, err, "video is 100000x1080")
+	assert.ErrorContains(t, err, "video duration")
+}
+
+func TestClaimBuilderLanguages(t *testing.T) {
+	languagesOf := func(claim *pb.Claim) []pb.Language_Language {
+		var languages []pb.Language_Language
+		for _, language := range claim.GetLanguages() {
+			languages = append(languages, language.GetLanguage())
+		}
+		return languages
+	}
+
+	tests := []struct {
+		builder   *ClaimBuilder
+		languages []pb.Language_Language
+		v3        string
+	}{
+		{validBuilder().Languages(pb.Language_en, pb.Language_fr, pb.Language_es), []pb.Language_Language{pb.Language_en, pb.Language_fr, pb.Language_es}, "en"},
+		{validBuilder().Languages(pb.Language_en, pb.Language_en, pb.Language_fr), []pb.Language_Language{pb.Language_en, pb.Language_fr}, "en"},
+		{validBuilder().Languages(pb.Language_en, pb.Language_UNKNOWN_LANGUAGE), []pb.Language_Language{pb.Language_en}, "en"},
+		{validBuilder().Languages(pb.Language_UNKNOWN_LANGUAGE, pb.Language_de), []pb.Language_Language{pb.Language_de}, "de"},
+		{validBuilder().Languages(pb.Language_UNKNOWN_LANGUAGE), []pb.Language_Language{pb.Language_UNKNOWN_LANGUAGE}, ""},
+		{validBuilder().Languages(pb.Language_fr).Language(pb.Language_ja), []pb.Language_Language{pb.Language_ja}, "ja"},
+	}
+	for _, test := range tests {
+		claim, err := test.builder.Build()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, languagesOf(claim), test.languages)
+
+		v3Claim, err := toV3Claim(claim)
+		assert.NilError(t, err)
+		assert.Equal(t, v3Claim.Language, test.v3)
+	}
+}
@@ -0,0 +1,106 @@
+package stake
+
+import (
+	"encoding/hex"
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+// canonicalFixtures returns one claim of each type with most fields set. Their canonical bytes are pinned in
+// TestCanonicalBytes, so a protobuf upgrade that changes how claims serialize fails the test.
+func canonicalFixtures(t *testing.T) map[string]*pb.Claim {
+	stream, err := NewClaimBuilder().
+		Title("title").
+		Author("author").
+		Description("description").
+		Languages(pb.Language_en, pb.Language_fr).
+		License("CC-BY", "https://creativecommons.org/licenses/by/4.0/").
+		Thumbnail("https://example.com/thumbnail.jpg").
+		NSFW(true).
+		Fee(pb.Fee_LBC, 1.5, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6").
+		SDHash(testSDHash).
+		ContentType("video/mp4").
+		Video(60, 1920, 1080).
+		Build()
+	assert.NilError(t, err)
+
+	channel := newChannelClaim()
+	channel.Title = "channel"
+	channel.GetChannel().PublicKey = []byte{0x30, 0x56, 0x30, 0x10}
+	channel.GetChannel().Email = "channel@example.com"
+	channel.GetChannel().WebsiteUrl = "https://example.com"
+
+	repost, err := NewRepost("cf3f7c898af87cc69b06a6ac7899efb9a4878fdb")
+	assert.NilError(t, err)
+	collection, err := NewCollection([]string{"cf3f7c898af87cc69b06a6ac7899efb9a4878fdb", "2d7e3cd8cbd1f3b7d0ee0a8e2b0a1e6f0ab7f2f4"})
+	assert.NilError(t, err)
+	collection.Claim.Title = "collection"
+
+	return map[string]*pb.Claim{
+		"stream":     stream,
+		"channel":    channel,
+		"repost":     repost.Claim,
+		"collection": collection.Claim,
+	}
+}
+
+// canonicalBytes are the canonical bytes and hash of each of canonicalFixtures. If they change, claims serialized by
+// this version won't match signatures and dedup keys made by the previous one.
+var canonicalBytes = map[string]struct {
+	Bytes string
+	Hash  string
+}{
+	"stream": {
+		"42057469746c654a0b6465736372697074696f6e52232a2168747470733a2f2f6578616d706c652e636f6d2f7468756d626e61696c2e6a70675a066d617475726562020801620208300aaa010a3d2209766964656f2f6d70343230bd94033d13f4f3908708701caf565bfa09cfadf2f34fadf4a73fb86b295d1b21a7e64805994e45b5fbc650f30bac48741206617574686f721a0543432d4259222c68747470733a2f2f6372656174697665636f6d6d6f6e732e6f72672f6c6963656e7365732f62792f342e302f32220801121955ae2940f56e5bef2bd02049731446cc53c703ced2b0c2bcc11880a3c3475a0808800f10b808183c",
+		"2c90254a71c66e079278eced1cc0f7cbf9c5bfe019839a1541b31052d06008df",
+	},
+	"channel": {
+		"42076368616e6e656c12300a043056301012136368616e6e656c406578616d706c652e636f6d1a1368747470733a2f2f6578616d706c652e636f6d",
+		"c63d452364402c42672e5f5a54092a808b0b0f5468f665c4a28e67d851847c67",
+	},
+	"repost": {
+		"22160a14db8f87a4b9ef9978aca6069bc67cf88a897c3fcf",
+		"7314f60b279842f07cb898b3ae1a4dd05a9dbe31b4907b946eba895fba002e30",
+	},
+	"collection": {
+		"420a636f6c6c656374696f6e1a3012160a14db8f87a4b9ef9978aca6069bc67cf88a897c3fcf12160a14f4f2b70a6f1e0a2b8e0aeed0b7f3d1cbd83c7e2d",
+		"89d7c7e0fcc0134e83f4a9c44fd6022cccb003a4649c009d32a90e03b2b033d7",
+	},
+}
+
+func TestCanonicalBytes(t *testing.T) {
+	fixtures := canonicalFixtures(t)
+	assert.Equal(t, len(fixtures), len(canonicalBytes))
+	for name, claim := range fixtures {
+		canonical, err := CanonicalBytes(claim)
+		assert.NilError(t, err, name)
+		assert.Equal(t, hex.EncodeToString(canonical), canonicalBytes[name].Bytes, name)
+
+		hash, err := Hash(claim)
+		assert.NilError(t, err, name)
+		assert.Equal(t, hex.EncodeToString(hash), canonicalBytes[name].Hash, name)
+
+		value, err := (&StakeHelper{Claim: claim, Version: NoSig}).CompileValue()
+		assert.NilError(t, err, name)
+		assert.DeepEqual(t, value[1:], canonical)
+	}
+
+	_, err := CanonicalBytes(nil)
+	assert.ErrorContains(t, err, "claim is nil")
+}
+
+func TestCanonicalBytesUnknownFields(t *testing.T) {
+	claim := canonicalFixtures(t)["stream"]
+	// field 99 as a varint, which no version of the schema has
+	unknownField := []byte{0x98, 0x06, 0x01}
+	claim.XXX_unrecognized = unknownField
+	claim.GetStream().XXX_unrecognized = unknownField
+
+	canonical, err := CanonicalBytes(claim)
+	assert.NilError(t, err)
+	assert.Equal(t, hex.EncodeToString(canonical), canonicalBytes["stream"].Bytes)
+	assert.DeepEqual(t, claim.GetStream().XXX_unrecognized, unknownField)
+}
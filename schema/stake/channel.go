@@ -0,0 +1,45 @@
+package stake
+
+import (
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/schema/keys"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// NewChannelClaim creates an unsigned channel claim holding the DER encoded public key. Only SECP256k1 keys are
+// supported since those are the only keys the network can verify claim signatures with.
+func NewChannelClaim(pubKey *btcec.PublicKey) (*StakeHelper, error) {
+	if pubKey == nil {
+		return nil, errors.Err("a public key is required to create a channel claim")
+	}
+	if pubKey.Curve != btcec.S256() {
+		return nil, errors.Err("unsupported key type, channel keys must be %s", SECP256k1)
+	}
+
+	pubKeyBytes, err := keys.PublicKeyToDER(pubKey)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+
+	helper := &StakeHelper{Claim: newChannelClaim(), Version: NoSig}
+	helper.Claim.GetChannel().PublicKey = pubKeyBytes
+
+	return helper, nil
+}
+
+// NewChannelKey generates a new SECP256k1 key pair for a channel. The DER encoded private key is returned alongside
+// the key itself, it is what the caller has to store in the wallet to be able to sign with the channel later on.
+func NewChannelKey() (*btcec.PrivateKey, []byte, error) {
+	privateKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+
+	privateKeyBytes, err := keys.PrivateKeyToDER(privateKey)
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+
+	return privateKey, privateKeyBytes, nil
+}
@@ -0,0 +1,103 @@
+package stake
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/schema/keys"
+
+	"github.com/btcsuite/btcd/btcec"
+	"gotest.tools/assert"
+)
+
+func TestNewChannelClaim(t *testing.T) {
+	privateKey, privateKeyBytes, err := NewChannelKey()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	decodedKey, _, err := keys.GetPrivateKeyFromBytes(privateKeyBytes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Assert(t, decodedKey.D.Cmp(privateKey.D) == 0, "private key does not survive DER encoding")
+
+	channel, err := NewChannelClaim(privateKey.PubKey())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	channel.Claim.Title = "Test Channel"
+
+	rawChannel, err := channel.CompileValue()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	channel, err = DecodeClaimBytes(rawChannel, "lbrycrd_main")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	pubKey, err := channel.GetPublicKey()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Assert(t, bytes.Equal(pubKey.SerializeCompressed(), privateKey.PubKey().SerializeCompressed()))
+
+	claimID := "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb"                      //Fake
+	txid := "4c1df9e022e396859175f9bfa69b38e444db10fb53355fa99a0989a83bcdb82f" //Fake
+	claimIDHexBytes, err := hex.DecodeString(claimID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	claim := &StakeHelper{Claim: newStreamClaim(), ClaimID: reverseBytes(claimIDHexBytes), Version: WithSig}
+	claim.Claim.Title = "Signed by a new channel"
+	sig, err := Sign(*privateKey, *channel, *claim, txid)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	claim.Signature, err = sig.LBRYSDKEncode()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	rawClaim, err := claim.CompileValue()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	claim, err = DecodeClaimBytes(rawClaim, "lbrycrd_main")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	valid, err := claim.ValidateClaimSignature(channel, txid, claimID, "lbrycrd_main")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Assert(t, valid, "could not verify signature")
+}
+
+func TestNewChannelClaimUnsupportedCurve(t *testing.T) {
+	nistKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = NewChannelClaim((*btcec.PublicKey)(&nistKey.PublicKey))
+	assert.Assert(t, err != nil, "NIST256p keys should be rejected")
+
+	_, err = NewChannelClaim(nil)
+	assert.Assert(t, err != nil, "a nil key should be rejected")
+}
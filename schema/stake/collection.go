@@ -0,0 +1,54 @@
+package stake
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// NewCollection creates an unsigned collection claim listing the given claims in order. Title, description and
+// thumbnail are set on the returned claim the same way as for streams. Long lists can exceed MaxSerializedSize, so
+// the collection is checked with CheckSize, which should be repeated once metadata has been added.
+func NewCollection(claimIDs []string) (*StakeHelper, error) {
+	references := make([]*pb.ClaimReference, len(claimIDs))
+	for i, claimID := range claimIDs {
+		claimHash, err := decodeClaimID(claimID)
+		if err != nil {
+			return nil, errors.Prefix(fmt.Sprintf("collection item %d", i), err)
+		}
+		references[i] = &pb.ClaimReference{ClaimHash: claimHash}
+	}
+
+	claim := &pb.Claim{Type: &pb.Claim_Collection{Collection: &pb.ClaimList{
+		ListType:        pb.ClaimList_COLLECTION,
+		ClaimReferences: references,
+	}}}
+	err := CheckSize(claim)
+	if err != nil {
+		return nil, err
+	}
+	return &StakeHelper{Claim: claim, Version: NoSig}, nil
+}
+
+// IsCollection reports whether the claim is a collection of other claims.
+func (c *StakeHelper) IsCollection() bool {
+	return c.IsClaim() && c.Claim.GetCollection() != nil
+}
+
+// CollectionClaimIDs returns the ids of the claims in the collection, in order.
+func (c *StakeHelper) CollectionClaimIDs() ([]string, error) {
+	if !c.IsCollection() {
+		return nil, errors.Err("claim is not a collection")
+	}
+	references := c.Claim.GetCollection().GetClaimReferences()
+	claimIDs := make([]string, len(references))
+	for i, reference := range references {
+		if len(reference.GetClaimHash()) != claimIDLength {
+			return nil, errors.Err("collection item %d is %d bytes, expected %d", i, len(reference.GetClaimHash()), claimIDLength)
+		}
+		claimIDs[i] = hex.EncodeToString(reverseBytes(reference.GetClaimHash()))
+	}
+	return claimIDs, nil
+}
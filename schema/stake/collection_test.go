@@ -0,0 +1,84 @@
+package stake
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNewCollection(t *testing.T) {
+	claimIDs := []string{
+		"cf3f7c898af87cc69b06a6ac7899efb9a4878fdb",
+		"2d7e3cd8cbd1f3b7d0ee0a8e2b0a1e6f0ab7f2f4",
+		"cf3f7c898af87cc69b06a6ac7899efb9a4878fdb",
+	}
+	collection, err := NewCollection(claimIDs)
+	assert.NilError(t, err)
+	collection.Claim.Title = "playlist"
+	collection.Claim.Description = "a few favourites"
+	assert.NilError(t, Validate(collection.Claim))
+
+	value, err := collection.CompileValue()
+	assert.NilError(t, err)
+	decoded, err := DecodeClaimBytes(value, "lbrycrd_main")
+	assert.NilError(t, err)
+
+	assert.Assert(t, decoded.IsCollection())
+	assert.Equal(t, claimTypeName(decoded.Claim), "collection")
+	assert.Equal(t, decoded.Claim.GetTitle(), "playlist")
+	decodedIDs, err := decoded.CollectionClaimIDs()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decodedIDs, claimIDs)
+}
+
+func TestNewCollectionEmpty(t *testing.T) {
+	collection, err := NewCollection(nil)
+	assert.NilError(t, err)
+	assert.NilError(t, Validate(collection.Claim))
+
+	value, err := collection.CompileValue()
+	assert.NilError(t, err)
+	decoded, err := DecodeClaimBytes(value, "lbrycrd_main")
+	assert.NilError(t, err)
+	assert.Assert(t, decoded.IsCollection())
+	claimIDs, err := decoded.CollectionClaimIDs()
+	assert.NilError(t, err)
+	assert.Equal(t, len(claimIDs), 0)
+}
+
+func TestNewCollectionErrors(t *testing.T) {
+	valid := "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb"
+	for _, claimID := range []string{"", "cf3f7c898af87cc69b06a6ac7899efb9a4878f", "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb00", "zz3f7c898af87cc69b06a6ac7899efb9a4878fdb"} {
+		_, err := NewCollection([]string{valid, claimID})
+		assert.ErrorContains(t, err, "collection item 1", claimID)
+	}
+
+	collection, err := NewCollection([]string{valid, valid})
+	assert.NilError(t, err)
+	references := collection.Claim.GetCollection().GetClaimReferences()
+	references[1].ClaimHash = references[1].ClaimHash[:19]
+	assert.ErrorContains(t, Validate(collection.Claim), "collection item 1 is 19 bytes")
+	_, err = collection.CollectionClaimIDs()
+	assert.ErrorContains(t, err, "collection item 1 is 19 bytes")
+
+	stream := &StakeHelper{Claim: validStreamClaim(t)}
+	assert.Assert(t, !stream.IsCollection())
+	_, err = stream.CollectionClaimIDs()
+	assert.ErrorContains(t, err, "not a collection")
+}
+
+func TestNewCollectionTooLarge(t *testing.T) {
+	claimIDs := make([]string, MaxSerializedSize/claimIDLength)
+	for i := range claimIDs {
+		claimIDs[i] = fmt.Sprintf("%040x", i)
+	}
+	_, err := NewCollection(claimIDs)
+	assert.ErrorContains(t, err, "more than the maximum")
+
+	collection, err := NewCollection(claimIDs[:100])
+	assert.NilError(t, err)
+	collection.Claim.Description = strings.Repeat("x", MaxSerializedSize)
+	assert.ErrorContains(t, Validate(collection.Claim), "collection is")
+}
@@ -0,0 +1,56 @@
+package stake
+
+import (
+	"sort"
+
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// NamedClaim is a claim together with the parts of its output that aren't in the claim value.
+type NamedClaim struct {
+	Name    string
+	ClaimID string
+	// Bid is the amount of the claim plus its supports, in dewies.
+	Bid   uint64
+	Claim *pb.Claim
+}
+
+// Collision is a normalized name that more than one claim was made for.
+type Collision struct {
+	Name     string
+	ClaimIDs []string
+	// Winner is the claim id with the highest bid, which is the one the name resolves to.
+	Winner string
+}
+
+// DetectNamespaceCollisions groups claims by their normalized name and returns every name that more than one claim
+// competes for, sorted by name. When bids are equal the claim that comes first in claims wins, since there is no
+// height to break the tie with.
+func DetectNamespaceCollisions(claims []NamedClaim) []Collision {
+	byName := make(map[string][]int, len(claims))
+	for i, claim := range claims {
+		// an invalid name still competes for its normalized form on chain
+		name, _ := NormalizeClaimName(claim.Name)
+		byName[name] = append(byName[name], i)
+	}
+
+	var collisions []Collision
+	for name, indexes := range byName {
+		if len(indexes) < 2 {
+			continue
+		}
+		collision := Collision{Name: name, ClaimIDs: make([]string, len(indexes))}
+		winner := indexes[0]
+		for i, index := range indexes {
+			collision.ClaimIDs[i] = claims[index].ClaimID
+			if claims[index].Bid > claims[winner].Bid {
+				winner = index
+			}
+		}
+		collision.Winner = claims[winner].ClaimID
+		collisions = append(collisions, collision)
+	}
+
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i].Name < collisions[j].Name })
+	return collisions
+}
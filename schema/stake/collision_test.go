@@ -0,0 +1,41 @@
+package stake
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDetectNamespaceCollisions(t *testing.T) {
+	claims := []NamedClaim{
+		{Name: "video", ClaimID: "a", Bid: 100},
+		{Name: "Video", ClaimID: "b", Bid: 200},
+		{Name: "VIDEO", ClaimID: "c", Bid: 50},
+		{Name: "other", ClaimID: "d", Bid: 1},
+		{Name: "@channel", ClaimID: "e", Bid: 10},
+		{Name: "@Channel", ClaimID: "f", Bid: 10},
+		{Name: "channel", ClaimID: "g", Bid: 1000},
+		{Name: "caf\u00e9", ClaimID: "h", Bid: 1},
+		{Name: "cafe\u0301", ClaimID: "i", Bid: 2},
+	}
+
+	assert.DeepEqual(t, DetectNamespaceCollisions(claims), []Collision{
+		{Name: "@channel", ClaimIDs: []string{"e", "f"}, Winner: "e"},
+		{Name: "cafe\u0301", ClaimIDs: []string{"h", "i"}, Winner: "i"},
+		{Name: "video", ClaimIDs: []string{"a", "b", "c"}, Winner: "b"},
+	})
+	assert.Assert(t, DetectNamespaceCollisions(nil) == nil)
+	assert.Assert(t, DetectNamespaceCollisions(claims[3:5]) == nil)
+}
+
+func BenchmarkDetectNamespaceCollisions(b *testing.B) {
+	claims := make([]NamedClaim, 10000)
+	for i := range claims {
+		claims[i] = NamedClaim{Name: fmt.Sprintf("Video-%d", i%7000), ClaimID: fmt.Sprintf("%040x", i), Bid: uint64(i)}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DetectNamespaceCollisions(claims)
+	}
+}
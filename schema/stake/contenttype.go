@@ -0,0 +1,114 @@
+package stake
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// SupportedContentTypes are the media types, without parameters, that the lbry clients can play or display. Callers
+// that publish other kinds of files can add to it.
+var SupportedContentTypes = map[string]bool{
+	"video/mp4":                true,
+	"video/webm":               true,
+	"video/ogg":                true,
+	"video/quicktime":          true,
+	"video/x-matroska":         true,
+	"video/x-msvideo":          true,
+	"audio/mpeg":               true,
+	"audio/mp4":                true,
+	"audio/ogg":                true,
+	"audio/flac":               true,
+	"audio/wav":                true,
+	"audio/webm":               true,
+	"image/jpeg":               true,
+	"image/png":                true,
+	"image/gif":                true,
+	"image/webp":               true,
+	"image/svg+xml":            true,
+	"text/plain":               true,
+	"text/markdown":            true,
+	"text/html":                true,
+	"application/pdf":          true,
+	"application/epub+zip":     true,
+	"application/msword":       true,
+	"application/rtf":          true,
+	"application/zip":          true,
+	"application/octet-stream": true,
+	"application/vnd.oasis.opendocument.text":                                 true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+}
+
+// ContentTypeNormalizations maps content types historical claims commonly got wrong, lower cased and without
+// parameters, to the media type they meant.
+var ContentTypeNormalizations = map[string]string{
+	"mp4":         "video/mp4",
+	"m4v":         "video/mp4",
+	"webm":        "video/webm",
+	"mkv":         "video/x-matroska",
+	"mov":         "video/quicktime",
+	"mp3":         "audio/mpeg",
+	"jpg":         "image/jpeg",
+	"jpeg":        "image/jpeg",
+	"png":         "image/png",
+	"gif":         "image/gif",
+	"pdf":         "application/pdf",
+	"txt":         "text/plain",
+	"video/mpeg4": "video/mp4",
+	"video/x-m4v": "video/mp4",
+	"video/mkv":   "video/x-matroska",
+	"audio/mp3":   "audio/mpeg",
+	"audio/x-wav": "audio/wav",
+	"image/jpg":   "image/jpeg",
+}
+
+// fallbackContentType is what lenient migration publishes a claim with when its content type isn't a media type.
+const fallbackContentType = "application/octet-stream"
+
+// NormalizeContentType parses a content type as an RFC 6838 media type, fixes it if it is one of the mistakes in
+// ContentTypeNormalizations and checks it against SupportedContentTypes. Parameters such as a charset are kept. It
+// returns the normalized content type and, if it differs from contentType, a description of the change.
+func NormalizeContentType(contentType string) (normalized string, fix string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", "", errors.Err("content type '%s' is not a valid media type", contentType)
+	}
+	if fixed, ok := ContentTypeNormalizations[mediaType]; ok {
+		mediaType = fixed
+	}
+	if !strings.Contains(mediaType, "/") {
+		return "", "", errors.Err("content type '%s' is not a valid media type", contentType)
+	}
+	if !SupportedContentTypes[mediaType] {
+		return "", "", errors.Err("content type '%s' is not supported", contentType)
+	}
+
+	normalized = mime.FormatMediaType(mediaType, params)
+	if normalized != contentType {
+		fix = fmt.Sprintf("normalized '%s' to '%s'", contentType, normalized)
+	}
+	return normalized, fix, nil
+}
+
+// normalizeContentType normalizes the content type with NormalizeContentType and returns any change as a warning. If
+// lenient, a content type that isn't supported is kept as is and one that isn't a media type at all is replaced with
+// fallbackContentType, with a warning either way. A missing content type is an error even then.
+func normalizeContentType(contentType string, lenient bool) (string, []string, error) {
+	normalized, fix, err := NormalizeContentType(contentType)
+	if err == nil {
+		if fix == "" {
+			return normalized, nil, nil
+		}
+		return normalized, []string{"content type: " + fix}, nil
+	}
+	if !lenient || strings.TrimSpace(contentType) == "" {
+		return "", nil, err
+	}
+
+	if validateMediaType(contentType) == nil {
+		return contentType, []string{fmt.Sprintf("content type: '%s' is not supported, kept as is", contentType)}, nil
+	}
+	return fallbackContentType, []string{fmt.Sprintf("content type: replaced '%s' with '%s'", contentType, fallbackContentType)}, nil
+}
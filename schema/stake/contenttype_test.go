@@ -0,0 +1,97 @@
+package stake
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNormalizeContentType(t *testing.T) {
+	tests := []struct {
+		ContentType string
+		Normalized  string
+		Fixed       bool
+	}{
+		{"video/mp4", "video/mp4", false},
+		{"text/plain; charset=utf-8", "text/plain; charset=utf-8", false},
+		{"Video/MP4", "video/mp4", true},
+		{"mp4", "video/mp4", true},
+		{" MP3 ", "audio/mpeg", true},
+		{"image/jpg", "image/jpeg", true},
+		{"text/plain;charset=UTF-8", "text/plain; charset=UTF-8", true},
+	}
+	for _, test := range tests {
+		normalized, fix, err := NormalizeContentType(test.ContentType)
+		assert.NilError(t, err, test.ContentType)
+		assert.Equal(t, normalized, test.Normalized)
+		assert.Equal(t, fix != "", test.Fixed, test.ContentType)
+	}
+
+	invalid := []struct {
+		ContentType string
+		Message     string
+	}{
+		{"", "is not a valid media type"},
+		{"video", "is not a valid media type"},
+		{"video/", "is not a valid media type"},
+		{"video/x-flv", "is not supported"},
+	}
+	for _, test := range invalid {
+		_, _, err := NormalizeContentType(test.ContentType)
+		assert.ErrorContains(t, err, test.Message, test.ContentType)
+	}
+}
+
+func TestSupportedContentTypesOverride(t *testing.T) {
+	_, _, err := NormalizeContentType("video/x-flv")
+	assert.ErrorContains(t, err, "is not supported")
+
+	SupportedContentTypes["video/x-flv"] = true
+	defer delete(SupportedContentTypes, "video/x-flv")
+	normalized, _, err := NormalizeContentType("video/x-flv")
+	assert.NilError(t, err)
+	assert.Equal(t, normalized, "video/x-flv")
+}
+
+func TestBuilderContentType(t *testing.T) {
+	claim, err := validBuilder().ContentType("mp4").Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetSource().GetMediaType(), "video/mp4")
+
+	_, err = validBuilder().ContentType("video").Build()
+	assert.ErrorContains(t, err, "is not a valid media type")
+	_, err = validBuilder().ContentType("video/x-flv").Build()
+	assert.ErrorContains(t, err, "is not supported")
+}
+
+func TestMigrateContentType(t *testing.T) {
+	tests := []struct {
+		ContentType string
+		MediaType   string
+		Warning     string
+	}{
+		{"video/mp4", "video/mp4", ""},
+		{"mp4", "video/mp4", "content type: normalized 'mp4' to 'video/mp4'"},
+		{"video", "application/octet-stream", "content type: replaced 'video' with 'application/octet-stream'"},
+		{"video/x-flv", "video/x-flv", "content type: 'video/x-flv' is not supported, kept as is"},
+	}
+	for _, test := range tests {
+		value := []byte(`{"ver": "0.0.3", "title": "content type", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "` + test.ContentType + `"}`)
+		claim, warnings, err := MigrateFromJSONWithWarnings(value, MigrationOptions{})
+		assert.NilError(t, err, test.ContentType)
+		assert.Equal(t, claim.GetStream().GetSource().GetMediaType(), test.MediaType)
+		if test.Warning == "" {
+			assert.Equal(t, len(warnings), 0, test.ContentType)
+		} else {
+			assert.DeepEqual(t, warnings, []string{test.Warning})
+		}
+
+		claim, _, err = MigrateFromJSONWithWarnings(value, MigrationOptions{Strict: true})
+		if test.ContentType == "video" || test.ContentType == "video/x-flv" {
+			assert.ErrorContains(t, err, "content type", test.ContentType)
+		} else {
+			assert.NilError(t, err, test.ContentType)
+			assert.Equal(t, claim.GetStream().GetSource().GetMediaType(), test.MediaType)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package stake
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// ClaimVersion is the schema version a raw claim value was written in.
+type ClaimVersion int
+
+const (
+	VersionUnknown ClaimVersion = iota
+	VersionV1
+	VersionV2
+	VersionV3
+	// VersionProtobufHex is a hex encoded protobuf claim, which is what already migrated claims look like.
+	VersionProtobufHex
+)
+
+var claimVersionNames = map[ClaimVersion]string{
+	VersionUnknown:     "unknown",
+	VersionV1:          "v1",
+	VersionV2:          "v2",
+	VersionV3:          "v3",
+	VersionProtobufHex: "protobuf-hex",
+}
+
+func (v ClaimVersion) String() string {
+	if name, ok := claimVersionNames[v]; ok {
+		return name
+	}
+	return claimVersionNames[VersionUnknown]
+}
+
+var jsonClaimVersions = map[string]ClaimVersion{
+	"0.0.1": VersionV1,
+	"0.0.2": VersionV2,
+	"0.0.3": VersionV3,
+}
+
+// DetectVersion classifies a raw claim value by schema version without unmarshaling it. The "ver" field decides for
+// json claims, claims without one are only considered V1 if they have sources and none of the fields introduced
+// later. Well formed values that can't be classified are reported as VersionUnknown without an error.
+func DetectVersion(raw []byte) (ClaimVersion, error) {
+	version, _, err := detectVersion(raw)
+	return version, err
+}
+
+// detectVersion works like DetectVersion but also returns the raw value of the "ver" field.
+func detectVersion(raw []byte) (ClaimVersion, string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return VersionUnknown, "", errors.Err("there is nothing to detect")
+	}
+	if trimmed[0] != '{' {
+		if isProtobufHex(trimmed) {
+			return VersionProtobufHex, "", nil
+		}
+		return VersionUnknown, "", errors.Err("claim value is neither a json object nor a hex encoded protobuf")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if _, err := decoder.Token(); err != nil {
+		return VersionUnknown, "", errors.Err(err)
+	}
+
+	rawVersion := ""
+	hasVersion, hasSources, hasNewerFields := false, false, false
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return VersionUnknown, "", errors.Err(err)
+		}
+		key, _ := token.(string)
+		value, err := decoder.Token()
+		if err != nil {
+			return VersionUnknown, "", errors.Err(err)
+		}
+
+		switch key {
+		case "ver":
+			hasVersion = true
+			switch v := value.(type) {
+			case string:
+				rawVersion = v
+			case json.Number:
+				rawVersion = v.String()
+			}
+		case "sources":
+			hasSources = value == json.Delim('{')
+		case "license_url", "content_type":
+			hasNewerFields = true
+		}
+
+		if err = skipValue(decoder, value); err != nil {
+			return VersionUnknown, "", err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return VersionUnknown, "", errors.Err(err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return VersionUnknown, "", errors.Err("unexpected data after the claim value")
+	}
+
+	if hasVersion && rawVersion != "" {
+		return jsonClaimVersions[rawVersion], rawVersion, nil
+	}
+	if hasSources && !hasNewerFields {
+		return VersionV1, rawVersion, nil
+	}
+	return VersionUnknown, rawVersion, nil
+}
+
+// skipValue consumes the rest of a json value whose first token has already been read.
+func skipValue(decoder *json.Decoder, first json.Token) error {
+	if first != json.Delim('{') && first != json.Delim('[') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		token, err := decoder.Token()
+		if err != nil {
+			return errors.Err(err)
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+// isProtobufHex checks whether value is hex that starts with a byte a serialized claim can start with: the unsigned
+// or signed version byte, or the version field tag of a legacy protobuf claim.
+func isProtobufHex(value []byte) bool {
+	if len(value) < 2 || len(value)%2 != 0 {
+		return false
+	}
+	for _, c := range value {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	first, err := hex.DecodeString(string(value[:2]))
+	if err != nil {
+		return false
+	}
+	return first[0] == NoSig.byte() || first[0] == WithSig.byte() || first[0] == 0x08
+}
@@ -0,0 +1,47 @@
+package stake
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDetectVersion(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Raw     string
+		Version ClaimVersion
+		Error   bool
+	}{
+		{"v1 with version", `{"ver": "0.0.1", "title": "a", "sources": {"lbry_sd_hash": "00"}}`, VersionV1, false},
+		{"v1 without version", `{"title": "a", "sources": {"lbry_sd_hash": "00"}, "content-type": "video/mp4"}`, VersionV1, false},
+		{"v2", `{"ver": "0.0.2", "license_url": "https://example.com", "sources": {"lbry_sd_hash": "00"}}`, VersionV2, false},
+		{"v3", `{"ver": "0.0.3", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "00"}}`, VersionV3, false},
+		{"version wins over fields", `{"ver": "0.0.2", "content_type": "video/mp4"}`, VersionV2, false},
+		{"unknown version", `{"ver": "0.0.9", "sources": {}}`, VersionUnknown, false},
+		{"numeric version", `{"ver": 1, "sources": {}}`, VersionUnknown, false},
+		{"no version with newer fields", `{"license_url": "https://example.com", "sources": {"lbry_sd_hash": "00"}}`, VersionUnknown, false},
+		{"no version with sources that are not an object", `{"sources": "00"}`, VersionUnknown, false},
+		{"nested values are skipped", `{"fee": {"LBC": {"amount": [1, {"ver": "0.0.3"}]}}, "sources": {"a": []}}`, VersionV1, false},
+		{"empty object", `{}`, VersionUnknown, false},
+		{"unsigned protobuf hex", "000a0474657374", VersionProtobufHex, false},
+		{"signed protobuf hex", "01" + "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb", VersionProtobufHex, false},
+		{"legacy protobuf hex", "080110011a5c", VersionProtobufHex, false},
+		{"odd length hex", "0a04747", VersionUnknown, true},
+		{"hex with an unexpected first byte", "ff00", VersionUnknown, true},
+		{"truncated json", `{"ver": "0.0.3", "title": `, VersionUnknown, true},
+		{"trailing garbage", `{"ver": "0.0.3"} {}`, VersionUnknown, true},
+		{"array", `[{"ver": "0.0.3"}]`, VersionUnknown, true},
+		{"empty", "  ", VersionUnknown, true},
+	}
+
+	for _, test := range tests {
+		version, err := DetectVersion([]byte(test.Raw))
+		if test.Error {
+			assert.Assert(t, err != nil, test.Name)
+		} else {
+			assert.NilError(t, err, test.Name)
+		}
+		assert.Equal(t, version, test.Version, test.Name)
+	}
+}
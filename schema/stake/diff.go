@@ -0,0 +1,166 @@
+package stake
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/schema/address/base58"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// FieldChange is a field that differs between two claims. Old or New is empty if the field is only set on one side.
+type FieldChange struct {
+	Path string
+	Old  string
+	New  string
+}
+
+func (f FieldChange) String() string {
+	return fmt.Sprintf("%s: %q -> %q", f.Path, f.Old, f.New)
+}
+
+// Diff lists the fields that differ between two claims, sorted by path. Paths follow the protobuf field names, e.g.
+// "stream.fee.amount" or "languages[0].language". Unset and empty fields are treated the same, so a nil thumbnail and
+// an empty one don't count as a change, and fee addresses are compared in their base58 form. Claims of different
+// types show up as a change of "type" along with the fields of each.
+func Diff(a, b *pb.Claim) []FieldChange {
+	before, after := claimFields(a), claimFields(b)
+
+	var changes []FieldChange
+	for path, old := range before {
+		if updated := after[path]; updated != old {
+			changes = append(changes, FieldChange{Path: path, Old: old, New: updated})
+		}
+	}
+	for path, updated := range after {
+		if _, ok := before[path]; !ok {
+			changes = append(changes, FieldChange{Path: path, New: updated})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// Equal reports whether two claims have no meaningful differences according to Diff.
+func Equal(a, b *pb.Claim) bool {
+	return len(Diff(a, b)) == 0
+}
+
+// updateFields are the paths of the fields a viewer sees, which NeedsUpdate compares. The mature flag is a tag.
+var updateFields = []string{
+	"type",
+	"title",
+	"description",
+	"thumbnail",
+	"tags",
+	"languages",
+	"stream.license",
+	"stream.license_url",
+	"stream.release_time",
+}
+
+// NeedsUpdate reports whether a fresh claim differs from the stored one in any field a viewer sees: the title,
+// description, thumbnail, tags (which carry the mature flag), languages, license and release time. Other differences,
+// such as in the source, don't call for an update. Fields are compared the way Diff compares them.
+func NeedsUpdate(stored *pb.Claim, fresh *pb.Claim) bool {
+	for _, change := range Diff(stored, fresh) {
+		if isUpdateField(change.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUpdateField(path string) bool {
+	for _, field := range updateFields {
+		if path == field || strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func claimFields(claim *pb.Claim) map[string]string {
+	fields := map[string]string{"type": claimTypeName(claim)}
+	if claim != nil {
+		flattenFields("", reflect.ValueOf(claim), fields)
+	}
+	return fields
+}
+
+// flattenFields adds every set field of a protobuf message to fields, keyed by its path.
+func flattenFields(path string, v reflect.Value, fields map[string]string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			flattenFields(path, v.Elem(), fields)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if strings.HasPrefix(field.Name, "XXX_") {
+				continue
+			}
+			if _, isOneof := field.Tag.Lookup("protobuf_oneof"); isOneof {
+				// the oneof wrapper holds a single field, whose name is the one we want in the path
+				flattenFields(path, v.Field(i), fields)
+				continue
+			}
+			flattenFields(joinPath(path, protobufName(field)), v.Field(i), fields)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Len() > 0 {
+				fields[path] = bytesField(path, v.Bytes())
+			}
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			flattenFields(fmt.Sprintf("%s[%d]", path, i), v.Index(i), fields)
+		}
+	default:
+		if !v.IsZero() {
+			fields[path] = fmt.Sprint(v.Interface())
+		}
+	}
+}
+
+func bytesField(path string, value []byte) string {
+	if strings.HasSuffix(path, "fee.address") {
+		return normalizeAddress(value)
+	}
+	return hex.EncodeToString(value)
+}
+
+// normalizeAddress returns the base58 form of an address whether it is stored as raw bytes or as base58 text.
+func normalizeAddress(value []byte) string {
+	if len(value) == addressLength && base58.VerifyBase58Checksum(value) {
+		return base58.EncodeBase58(value)
+	}
+	decoded, err := base58.DecodeBase58(string(value), addressLength)
+	if err == nil && base58.VerifyBase58Checksum(decoded) {
+		return string(value)
+	}
+	return hex.EncodeToString(value)
+}
+
+func protobufName(field reflect.StructField) string {
+	for _, part := range strings.Split(field.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return strings.ToLower(field.Name)
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,102 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/schema/address"
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a := validStreamClaim(t)
+	b := validStreamClaim(t)
+	assert.Assert(t, Equal(a, b))
+
+	b.Title = "new title"
+	b.GetStream().Fee = &pb.Fee{Currency: pb.Fee_LBC, Amount: 100000000}
+	b.Languages = []*pb.Language{{Language: pb.Language_fr}}
+	assert.DeepEqual(t, Diff(a, b), []FieldChange{
+		{Path: "languages[0].language", New: "fr"},
+		{Path: "stream.fee.amount", New: "100000000"},
+		{Path: "stream.fee.currency", New: "LBC"},
+		{Path: "title", Old: "title", New: "new title"},
+	})
+	assert.Assert(t, !Equal(a, b))
+}
+
+func TestDiffIgnoresEmptyFields(t *testing.T) {
+	a := validStreamClaim(t)
+	b := validStreamClaim(t)
+	b.Thumbnail = &pb.Source{}
+	b.Tags = []string{}
+	b.GetStream().Fee = nil
+	a.GetStream().Fee = &pb.Fee{}
+	assert.Assert(t, Equal(a, b), "%v", Diff(a, b))
+}
+
+func TestDiffFeeAddressEncoding(t *testing.T) {
+	feeAddress := "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"
+	decoded, err := address.DecodeAddress(feeAddress, "lbrycrd_main")
+	assert.NilError(t, err)
+
+	a := validStreamClaim(t)
+	a.GetStream().Fee = &pb.Fee{Currency: pb.Fee_LBC, Amount: 1, Address: decoded[:]}
+	b := validStreamClaim(t)
+	b.GetStream().Fee = &pb.Fee{Currency: pb.Fee_LBC, Amount: 1, Address: []byte(feeAddress)}
+	assert.Assert(t, Equal(a, b), "%v", Diff(a, b))
+
+	b.GetStream().GetFee().Address = []byte("bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN")
+	assert.DeepEqual(t, Diff(a, b), []FieldChange{
+		{Path: "stream.fee.address", Old: feeAddress, New: "bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN"},
+	})
+}
+
+func TestDiffDifferentTypes(t *testing.T) {
+	stream := validStreamClaim(t)
+	channel := newChannelClaim()
+	channel.Title = "title"
+	channel.GetChannel().PublicKey = []byte{1, 2, 3}
+
+	changes := Diff(stream, channel)
+	assert.Equal(t, changes[len(changes)-1], FieldChange{Path: "type", Old: "stream", New: "channel"})
+	assert.Assert(t, !Equal(stream, channel))
+
+	// nil claims and claims with nil inner messages don't panic
+	assert.Assert(t, Equal(nil, nil))
+	assert.Assert(t, !Equal(nil, stream))
+	assert.Assert(t, Equal(&pb.Claim{Type: &pb.Claim_Stream{}}, &pb.Claim{Type: &pb.Claim_Stream{Stream: &pb.Stream{}}}))
+}
+
+func TestNeedsUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*pb.Claim)
+		update bool
+	}{
+		{"nothing", func(c *pb.Claim) {}, false},
+		{"empty thumbnail", func(c *pb.Claim) { c.Thumbnail = &pb.Source{} }, false},
+		{"source", func(c *pb.Claim) { c.GetStream().GetSource().Size = 1234 }, false},
+		{"fee", func(c *pb.Claim) { c.GetStream().Fee = &pb.Fee{Currency: pb.Fee_LBC, Amount: 1} }, false},
+		{"title", func(c *pb.Claim) { c.Title = "new title" }, true},
+		{"description", func(c *pb.Claim) { c.Description = "new description" }, true},
+		{"thumbnail", func(c *pb.Claim) { c.Thumbnail = &pb.Source{Url: "https://example.com/thumbnail.jpg"} }, true},
+		{"tags", func(c *pb.Claim) { c.Tags = append(c.Tags, "new") }, true},
+		{"mature", func(c *pb.Claim) { setMature(c) }, true},
+		{"language", func(c *pb.Claim) { c.Languages = []*pb.Language{{Language: pb.Language_fr}} }, true},
+		{"license", func(c *pb.Claim) { c.GetStream().License = "Public Domain" }, true},
+		{"license url", func(c *pb.Claim) { c.GetStream().LicenseUrl = "https://creativecommons.org/publicdomain/zero/1.0/" }, true},
+		{"release time", func(c *pb.Claim) { c.GetStream().ReleaseTime = 1546300800 }, true},
+	}
+	for _, test := range tests {
+		stored := validStreamClaim(t)
+		fresh := validStreamClaim(t)
+		test.change(fresh)
+		assert.Equal(t, NeedsUpdate(stored, fresh), test.update, test.name)
+	}
+
+	channel := newChannelClaim()
+	channel.Title = "title"
+	assert.Assert(t, NeedsUpdate(validStreamClaim(t), channel))
+}
@@ -0,0 +1,136 @@
+package stake
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// MediaInfo is what a MediaProber found out about a media file. Fields it couldn't find out are left at 0.
+type MediaInfo struct {
+	Duration int
+	Width    int
+	Height   int
+}
+
+// MediaProber reads the duration and dimensions of an audio or video file, e.g. by running ffprobe on it.
+type MediaProber func(path string) (MediaInfo, error)
+
+type fileOptions struct {
+	sdHash string
+	prober MediaProber
+}
+
+// BuilderOption configures ClaimFromFile.
+type BuilderOption func(*fileOptions)
+
+// WithSDHash sets the sd hash of the stream the daemon made from the file. It is required.
+func WithSDHash(sdHash string) BuilderOption {
+	return func(o *fileOptions) { o.sdHash = sdHash }
+}
+
+// WithMediaProber sets how the duration and dimensions of audio and video files are found. Without one the claim
+// has no audio or video metadata.
+func WithMediaProber(prober MediaProber) BuilderOption {
+	return func(o *fileOptions) { o.prober = prober }
+}
+
+// fileExtensionTypes are the content types of common media extensions, which the mime package only knows about if
+// the system has a mime.types file that lists them.
+var fileExtensionTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".webm": "video/webm",
+	".mkv":  "video/x-matroska",
+	".mov":  "video/quicktime",
+	".avi":  "video/x-msvideo",
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".ogg":  "audio/ogg",
+	".opus": "audio/ogg",
+	".flac": "audio/flac",
+	".wav":  "audio/wav",
+	".txt":  "text/plain; charset=utf-8",
+	".md":   "text/markdown; charset=utf-8",
+	".epub": "application/epub+zip",
+}
+
+// ClaimFromFile returns a builder for a claim of the file at path, with its content type and, for audio, video and
+// documents, its media metadata filled in. The caller only has to add the title, description, fee and so on. The
+// content type comes from the file extension, unless the contents of the file show it is of a different kind.
+func ClaimFromFile(path string, opts ...BuilderOption) (*ClaimBuilder, error) {
+	var o fileOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.sdHash == "" {
+		return nil, errors.Err("the sd hash of %s is required, set it with WithSDHash", path)
+	}
+	err := ValidateSDHash(o.sdHash)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, err := sniffContentType(path)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewClaimBuilder().SDHash(o.sdHash).ContentType(contentType)
+	switch family := mediaTypeFamily(contentType); {
+	case family == "video" || family == "audio":
+		if o.prober == nil {
+			break
+		}
+		info, err := o.prober(path)
+		if err != nil {
+			return nil, errors.Prefix("probing "+path, err)
+		}
+		if info.Duration < 0 || info.Width < 0 || info.Height < 0 {
+			return nil, errors.Err("%s has a negative duration, width or height", path)
+		}
+		if family == "video" {
+			builder.Video(uint32(info.Duration), uint32(info.Width), uint32(info.Height))
+		} else {
+			builder.Audio(uint32(info.Duration))
+		}
+	case validateDocumentType(contentType) == nil:
+		builder.Document()
+	}
+	return builder, nil
+}
+
+// sniffContentType works out the content type of a file from its extension and its first 512 bytes.
+func sniffContentType(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Err(err)
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", errors.Err(err)
+	}
+	sniffed := http.DetectContentType(head[:n])
+
+	ext := strings.ToLower(filepath.Ext(path))
+	byExtension, ok := fileExtensionTypes[ext]
+	if !ok {
+		byExtension = mime.TypeByExtension(ext)
+	}
+
+	if byExtension == "" {
+		return sniffed, nil
+	}
+	if sniffed != "application/octet-stream" && mediaTypeFamily(sniffed) != mediaTypeFamily(byExtension) {
+		return sniffed, nil
+	}
+	return byExtension, nil
+}
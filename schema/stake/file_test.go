@@ -0,0 +1,91 @@
+package stake
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"gotest.tools/assert"
+)
+
+func TestClaimFromFileVideo(t *testing.T) {
+	var probed string
+	prober := func(path string) (MediaInfo, error) {
+		probed = path
+		return MediaInfo{Duration: 12, Width: 640, Height: 360}, nil
+	}
+
+	builder, err := ClaimFromFile("testdata/sample.mp4", WithSDHash(testSDHash), WithMediaProber(prober))
+	assert.NilError(t, err)
+	claim, err := builder.Title("sample").Build()
+	assert.NilError(t, err)
+	assert.Equal(t, probed, "testdata/sample.mp4")
+	assert.Equal(t, claim.GetStream().GetSource().GetMediaType(), "video/mp4")
+	video := claim.GetStream().GetVideo()
+	assert.Equal(t, video.GetDuration(), uint32(12))
+	assert.Equal(t, video.GetWidth(), uint32(640))
+	assert.Equal(t, video.GetHeight(), uint32(360))
+
+	builder, err = ClaimFromFile("testdata/sample.mp4", WithSDHash(testSDHash))
+	assert.NilError(t, err)
+	claim, err = builder.Title("sample").Build()
+	assert.NilError(t, err)
+	assert.Assert(t, claim.GetStream().GetType() == nil)
+
+	_, err = ClaimFromFile("testdata/sample.mp4", WithSDHash(testSDHash), WithMediaProber(func(string) (MediaInfo, error) {
+		return MediaInfo{}, errors.Err("ffprobe not found")
+	}))
+	assert.ErrorContains(t, err, "ffprobe not found")
+
+	_, err = ClaimFromFile("testdata/sample.mp4", WithSDHash(testSDHash), WithMediaProber(func(string) (MediaInfo, error) {
+		return MediaInfo{Duration: 12, Width: -640, Height: 360}, nil
+	}))
+	assert.ErrorContains(t, err, "negative duration, width or height")
+}
+
+func TestClaimFromFileText(t *testing.T) {
+	builder, err := ClaimFromFile("testdata/sample.txt", WithSDHash(testSDHash))
+	assert.NilError(t, err)
+	claim, err := builder.Title("notes").Description("some notes").Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetSource().GetMediaType(), "text/plain; charset=utf-8")
+	assert.Equal(t, claim.GetDescription(), "some notes")
+}
+
+func TestClaimFromFileSniffing(t *testing.T) {
+	video, err := ioutil.ReadFile("testdata/sample.mp4")
+	assert.NilError(t, err)
+	text, err := ioutil.ReadFile("testdata/sample.txt")
+	assert.NilError(t, err)
+
+	tests := []struct {
+		Name        string
+		Contents    []byte
+		ContentType string
+	}{
+		{"no-extension", video, "video/mp4"},
+		{"mislabeled.txt", video, "video/mp4"},
+		{"mislabeled.mp4", text, "text/plain; charset=utf-8"},
+		{"episode.mp3", []byte{0x00, 0x01, 0x02}, "audio/mpeg"},
+		{"unknown", []byte{0x00, 0x01, 0x02}, "application/octet-stream"},
+	}
+	dir := t.TempDir()
+	for _, test := range tests {
+		path := filepath.Join(dir, test.Name)
+		assert.NilError(t, ioutil.WriteFile(path, test.Contents, 0600))
+		contentType, err := sniffContentType(path)
+		assert.NilError(t, err, test.Name)
+		assert.Equal(t, contentType, test.ContentType, test.Name)
+	}
+}
+
+func TestClaimFromFileErrors(t *testing.T) {
+	_, err := ClaimFromFile("testdata/sample.mp4")
+	assert.ErrorContains(t, err, "sd hash of testdata/sample.mp4 is required")
+	_, err = ClaimFromFile("testdata/sample.mp4", WithSDHash("abcd"))
+	assert.ErrorContains(t, err, "sd hash")
+	_, err = ClaimFromFile("testdata/missing.mp4", WithSDHash(testSDHash))
+	assert.Assert(t, err != nil)
+}
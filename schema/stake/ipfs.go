@@ -0,0 +1,104 @@
+package stake
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/schema/address/base58"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// SourceTypeIPFS is the key of an IPFS CID in the sources of a json claim, next to lbry_sd_hash.
+const SourceTypeIPFS = "ipfs_cid"
+
+// ipfsURLScheme marks a source url as an IPFS content address rather than a location.
+const ipfsURLScheme = "ipfs://"
+
+// cidV0Length is the length of a CIDv0, which is a base58 encoded sha2-256 multihash starting with "Qm".
+const (
+	cidV0Length      = 46
+	cidV0BytesLength = 34
+)
+
+// ConvertIPFSToCID decodes the string form of an IPFS CID to its binary form. CIDv0 ("Qm...") and CIDv1 in the
+// base32 multibase ("b...", the default for CIDv1) are supported. The result is checked to be a well formed CID with
+// a multihash whose digest is as long as it says.
+func ConvertIPFSToCID(cidStr string) ([]byte, error) {
+	cidStr = strings.TrimSpace(cidStr)
+	if cidStr == "" {
+		return nil, errors.Err("cid is empty")
+	}
+
+	if len(cidStr) == cidV0Length && strings.HasPrefix(cidStr, "Qm") {
+		decoded, err := base58.DecodeBase58(cidStr, cidV0BytesLength)
+		if err != nil {
+			return nil, errors.Prefix("cid '"+cidStr+"' is not base58", err)
+		}
+		err = checkMultihash(decoded)
+		if err != nil {
+			return nil, errors.Prefix("cid '"+cidStr+"'", err)
+		}
+		return decoded, nil
+	}
+
+	switch cidStr[0] {
+	case 'b', 'B':
+	default:
+		return nil, errors.Err("cid '%s' is neither a CIDv0 nor a base32 CIDv1", cidStr)
+	}
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(cidStr[1:]))
+	if err != nil {
+		return nil, errors.Prefix("cid '"+cidStr+"' is not base32", err)
+	}
+
+	version, n := binary.Uvarint(decoded)
+	if n <= 0 || version != 1 {
+		return nil, errors.Err("cid '%s' is not version 1", cidStr)
+	}
+	_, m := binary.Uvarint(decoded[n:])
+	if m <= 0 {
+		return nil, errors.Err("cid '%s' has no content type", cidStr)
+	}
+	err = checkMultihash(decoded[n+m:])
+	if err != nil {
+		return nil, errors.Prefix("cid '"+cidStr+"'", err)
+	}
+	return decoded, nil
+}
+
+// checkMultihash checks that a multihash is a hash function code and digest length followed by a digest that long.
+func checkMultihash(multihash []byte) error {
+	_, n := binary.Uvarint(multihash)
+	if n <= 0 {
+		return errors.Err("multihash has no hash function")
+	}
+	length, m := binary.Uvarint(multihash[n:])
+	if m <= 0 {
+		return errors.Err("multihash has no digest length")
+	}
+	if digest := multihash[n+m:]; uint64(len(digest)) != length || length == 0 {
+		return errors.Err("multihash digest is %d bytes, expected %d", len(digest), length)
+	}
+	return nil
+}
+
+// setIPFSSource adds an IPFS CID to a source once it checks out. The source keeps its sd hash and the hash of its
+// file, and the url is set to ipfs://<cid> so that clients know the content is also at that address.
+func setIPFSSource(source *pb.Source, cid string) error {
+	_, err := ConvertIPFSToCID(cid)
+	if err != nil {
+		return err
+	}
+	source.Url = ipfsURLScheme + strings.TrimSpace(cid)
+	return nil
+}
+
+// ipfsCID returns the CID a source was given by setIPFSSource, if it has one.
+func ipfsCID(source *pb.Source) string {
+	if strings.HasPrefix(source.GetUrl(), ipfsURLScheme) {
+		return strings.TrimPrefix(source.GetUrl(), ipfsURLScheme)
+	}
+	return ""
+}
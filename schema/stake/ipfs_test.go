@@ -0,0 +1,77 @@
+package stake
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+const testCIDv0 = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+
+// testCIDv1 is testCIDv0 as a CIDv1 with the dag-pb content type, in the base32 multibase.
+var testCIDv1 = func() string {
+	multihash, err := ConvertIPFSToCID(testCIDv0)
+	if err != nil {
+		panic(err)
+	}
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(append([]byte{0x01, 0x70}, multihash...))
+	return "b" + strings.ToLower(encoded)
+}()
+
+func TestConvertIPFSToCID(t *testing.T) {
+	v0, err := ConvertIPFSToCID(testCIDv0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(v0), cidV0BytesLength)
+	assert.Equal(t, hex.EncodeToString(v0[:2]), "1220")
+
+	v1, err := ConvertIPFSToCID(testCIDv1)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, v1, append([]byte{0x01, 0x70}, v0...))
+
+	v1Upper, err := ConvertIPFSToCID("B" + strings.ToUpper(testCIDv1[1:]))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, v1Upper, v1)
+
+	invalid := []string{
+		"",
+		"QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbd0",
+		"zdj7WWeQ43G6JJvLWQWZpyHuAMq6uYWRjkBXFad11vE2LHhQ7",
+		"b" + "1" + testCIDv1[2:],
+		testCIDv1[:len(testCIDv1)-4],
+		"bafy",
+	}
+	for _, cid := range invalid {
+		_, err := ConvertIPFSToCID(cid)
+		assert.Assert(t, err != nil, cid)
+	}
+}
+
+func TestMigrateIPFSSource(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "ipfs", "sources": {"lbry_sd_hash": "` + testSDHash + `", "ipfs_cid": "` + testCIDv1 + `"}, "content_type": "video/mp4"}`)
+	claim, err := MigrateFromJSON(value)
+	assert.NilError(t, err)
+	source := claim.GetStream().GetSource()
+	assert.Equal(t, hex.EncodeToString(source.GetSdHash()), testSDHash)
+	assert.Equal(t, source.GetUrl(), "ipfs://"+testCIDv1)
+	assert.Equal(t, len(source.GetHash()), 0)
+
+	v3Claim, err := toV3Claim(claim)
+	assert.NilError(t, err)
+	assert.Equal(t, v3Claim.Sources.IPFSCID, testCIDv1)
+
+	_, err = MigrateFromJSON([]byte(`{"ver": "0.0.3", "title": "ipfs", "sources": {"lbry_sd_hash": "` + testSDHash + `", "ipfs_cid": "Qm"}, "content_type": "video/mp4"}`))
+	assert.ErrorContains(t, err, "cid 'Qm'")
+}
+
+func TestSetIPFSSourceKeepsFileHash(t *testing.T) {
+	fileHash := []byte{0x0a, 0x0b, 0x0c}
+	source := &pb.Source{Hash: fileHash}
+	assert.NilError(t, setIPFSSource(source, testCIDv1))
+	assert.DeepEqual(t, source.GetHash(), fileHash)
+	assert.Equal(t, ipfsCID(source), testCIDv1)
+}
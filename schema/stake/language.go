@@ -0,0 +1,97 @@
+package stake
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// ErrUnknownLanguage is returned when a claim's language can't be resolved to a known language code.
+type ErrUnknownLanguage struct {
+	Language string
+}
+
+func (e ErrUnknownLanguage) Error() string {
+	return fmt.Sprintf("unknown language '%s'", e.Language)
+}
+
+// languageNames maps the english names of languages that show up in old json claims to their ISO 639-1 codes.
+var languageNames = map[string]string{
+	"arabic":     "ar",
+	"chinese":    "zh",
+	"czech":      "cs",
+	"dutch":      "nl",
+	"english":    "en",
+	"french":     "fr",
+	"german":     "de",
+	"greek":      "el",
+	"hebrew":     "he",
+	"hindi":      "hi",
+	"hungarian":  "hu",
+	"indonesian": "id",
+	"italian":    "it",
+	"japanese":   "ja",
+	"korean":     "ko",
+	"persian":    "fa",
+	"polish":     "pl",
+	"portuguese": "pt",
+	"romanian":   "ro",
+	"russian":    "ru",
+	"spanish":    "es",
+	"swedish":    "sv",
+	"thai":       "th",
+	"turkish":    "tr",
+	"ukrainian":  "uk",
+	"vietnamese": "vi",
+}
+
+// ResolveLanguage normalizes a free form language string into a language code. Matching is case insensitive, BCP-47
+// tags resolve to their primary subtag ("en-GB" and "en_US" are both en) and english language names are accepted. An
+// empty string resolves to UNKNOWN_LANGUAGE, anything else that can't be resolved returns ErrUnknownLanguage.
+func ResolveLanguage(raw string) (pb.Language_Language, error) {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	if normalized == "" {
+		return pb.Language_UNKNOWN_LANGUAGE, nil
+	}
+
+	if code, ok := languageNames[normalized]; ok {
+		normalized = code
+	} else if i := strings.IndexAny(normalized, "-_"); i > 0 {
+		normalized = normalized[:i]
+	}
+
+	if language, ok := pb.Language_Language_value[normalized]; ok && language != int32(pb.Language_UNKNOWN_LANGUAGE) {
+		return pb.Language_Language(language), nil
+	}
+	return pb.Language_UNKNOWN_LANGUAGE, errors.Err(ErrUnknownLanguage{Language: raw})
+}
+
+// ResolveLanguages resolves a claim's primary language and any additional ones with ResolveLanguage. The primary
+// language comes first, followed by the additional ones with duplicates and unknown languages left out.
+func ResolveLanguages(primary string, additional ...string) ([]pb.Language_Language, error) {
+	languages := make([]pb.Language_Language, 0, 1+len(additional))
+	for _, raw := range append([]string{primary}, additional...) {
+		language, err := ResolveLanguage(raw)
+		if err != nil {
+			return nil, err
+		}
+		languages = append(languages, language)
+	}
+	return uniqueLanguages(languages), nil
+}
+
+// uniqueLanguages drops UNKNOWN_LANGUAGE and repeated languages while keeping the order of the rest.
+func uniqueLanguages(languages []pb.Language_Language) []pb.Language_Language {
+	seen := make(map[pb.Language_Language]bool, len(languages))
+	unique := make([]pb.Language_Language, 0, len(languages))
+	for _, language := range languages {
+		if language == pb.Language_UNKNOWN_LANGUAGE || seen[language] {
+			continue
+		}
+		seen[language] = true
+		unique = append(unique, language)
+	}
+	return unique
+}
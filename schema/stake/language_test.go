@@ -0,0 +1,109 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+// languageTests covers the language values found in historical claim dumps.
+var languageTests = []struct {
+	Raw      string
+	Language pb.Language_Language
+}{
+	{"", pb.Language_UNKNOWN_LANGUAGE},
+	{"en", pb.Language_en},
+	{"EN", pb.Language_en},
+	{" en ", pb.Language_en},
+	{"en-US", pb.Language_en},
+	{"en-GB", pb.Language_en},
+	{"en_US", pb.Language_en},
+	{"English", pb.Language_en},
+	{"english", pb.Language_en},
+	{"es", pb.Language_es},
+	{"es-419", pb.Language_es},
+	{"Spanish", pb.Language_es},
+	{"fr", pb.Language_fr},
+	{"fr-CA", pb.Language_fr},
+	{"French", pb.Language_fr},
+	{"de", pb.Language_de},
+	{"German", pb.Language_de},
+	{"pt-BR", pb.Language_pt},
+	{"Portuguese", pb.Language_pt},
+	{"ru", pb.Language_ru},
+	{"Russian", pb.Language_ru},
+	{"zh", pb.Language_zh},
+	{"zh-Hans", pb.Language_zh},
+	{"Chinese", pb.Language_zh},
+	{"ja", pb.Language_ja},
+	{"Japanese", pb.Language_ja},
+	{"ko", pb.Language_ko},
+	{"it", pb.Language_it},
+	{"nl", pb.Language_nl},
+	{"pl", pb.Language_pl},
+	{"tr", pb.Language_tr},
+	{"ar", pb.Language_ar},
+	{"hi", pb.Language_hi},
+}
+
+func TestResolveLanguage(t *testing.T) {
+	for _, test := range languageTests {
+		language, err := ResolveLanguage(test.Raw)
+		if err != nil {
+			t.Errorf("resolving '%s': %v", test.Raw, err)
+			continue
+		}
+		assert.Equal(t, language, test.Language, test.Raw)
+	}
+
+	for _, raw := range []string{"engrish", "xx", "-en", "UNKNOWN_LANGUAGE", "klingon"} {
+		language, err := ResolveLanguage(raw)
+		langErr, ok := errors.Unwrap(err).(ErrUnknownLanguage)
+		if !ok {
+			t.Errorf("expected ErrUnknownLanguage for '%s', got %v", raw, err)
+			continue
+		}
+		assert.Equal(t, langErr.Language, raw)
+		assert.Equal(t, language, pb.Language_UNKNOWN_LANGUAGE)
+	}
+}
+
+func TestMigrateUnknownLanguage(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "typo", "language": "engrish", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`)
+
+	claim, err := MigrateFromJSON(value)
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetLanguages()[0].GetLanguage(), pb.Language_UNKNOWN_LANGUAGE)
+
+	claim, err = MigrateFromJSONWithOptions(value, MigrationOptions{Strict: true})
+	assert.Assert(t, claim == nil)
+	_, ok := errors.Unwrap(err).(ErrUnknownLanguage)
+	assert.Assert(t, ok, "expected ErrUnknownLanguage, got %v", err)
+}
+
+func TestResolveLanguages(t *testing.T) {
+	tests := []struct {
+		Primary    string
+		Additional []string
+		Languages  []pb.Language_Language
+	}{
+		{"en", nil, []pb.Language_Language{pb.Language_en}},
+		{"en", []string{"fr", "de-DE"}, []pb.Language_Language{pb.Language_en, pb.Language_fr, pb.Language_de}},
+		{"en", []string{"English", "en-GB", "fr"}, []pb.Language_Language{pb.Language_en, pb.Language_fr}},
+		{"", []string{"fr", ""}, []pb.Language_Language{pb.Language_fr}},
+		{"", nil, []pb.Language_Language{}},
+	}
+	for _, test := range tests {
+		languages, err := ResolveLanguages(test.Primary, test.Additional...)
+		assert.NilError(t, err, test.Primary)
+		assert.DeepEqual(t, languages, test.Languages)
+	}
+
+	_, err := ResolveLanguages("en", "fr", "klingon")
+	langErr, ok := errors.Unwrap(err).(ErrUnknownLanguage)
+	assert.Assert(t, ok, err)
+	assert.Equal(t, langErr.Language, "klingon")
+}
@@ -0,0 +1,93 @@
+package stake
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// ccLicenses are the Creative Commons licenses by their code, with the name the license text gives them.
+var ccLicenses = []struct {
+	Code string
+	Name string
+}{
+	{"by", "Attribution"},
+	{"by-sa", "Attribution-ShareAlike"},
+	{"by-nd", "Attribution-NoDerivatives"},
+	{"by-nc", "Attribution-NonCommercial"},
+	{"by-nc-sa", "Attribution-NonCommercial-ShareAlike"},
+	{"by-nc-nd", "Attribution-NonCommercial-NoDerivatives"},
+}
+
+// licenseURLs maps license names, normalized with licenseKey, to the url of the license text. A Creative Commons
+// license named without a version is taken to be 4.0.
+var licenseURLs = map[string]string{
+	licenseKey("Public Domain"):                     "https://creativecommons.org/publicdomain/mark/1.0/",
+	licenseKey("Public Domain Mark 1.0"):            "https://creativecommons.org/publicdomain/mark/1.0/",
+	licenseKey("CC0"):                               "https://creativecommons.org/publicdomain/zero/1.0/",
+	licenseKey("CC0 1.0"):                           "https://creativecommons.org/publicdomain/zero/1.0/",
+	licenseKey("CC0 1.0 Universal"):                 "https://creativecommons.org/publicdomain/zero/1.0/",
+	licenseKey("Creative Commons Zero"):             "https://creativecommons.org/publicdomain/zero/1.0/",
+	licenseKey("Standard YouTube License"):          "https://www.youtube.com/t/terms",
+	licenseKey("YouTube Standard License"):          "https://www.youtube.com/t/terms",
+	licenseKey("Standard License"):                  "https://www.youtube.com/t/terms",
+	licenseKey("Creative Commons Attribution"):      "https://creativecommons.org/licenses/by/4.0/",
+	licenseKey("Creative Commons Attribution Only"): "https://creativecommons.org/licenses/by/4.0/",
+}
+
+func init() {
+	for _, license := range ccLicenses {
+		code := strings.ToUpper(license.Code)
+		for _, version := range []string{"4.0", "3.0"} {
+			url := "https://creativecommons.org/licenses/" + license.Code + "/" + version + "/"
+			international := "International"
+			if version == "3.0" {
+				international = "Unported"
+			}
+			licenseURLs[licenseKey("Creative Commons "+license.Name+" "+version+" "+international)] = url
+			licenseURLs[licenseKey("Creative Commons "+license.Name+" "+version)] = url
+			licenseURLs[licenseKey("CC "+code+" "+version)] = url
+		}
+		url := "https://creativecommons.org/licenses/" + license.Code + "/4.0/"
+		licenseURLs[licenseKey("Creative Commons "+license.Name)] = url
+		licenseURLs[licenseKey("CC "+code)] = url
+	}
+}
+
+// LookupLicenseURL returns the url of the text of a license given its name, e.g. "Creative Commons Attribution 4.0
+// International", "CC BY-SA 4.0", "Public Domain" or "Standard YouTube License". Matching ignores case and
+// punctuation. It returns false for names it doesn't know, such as "Copyright".
+func LookupLicenseURL(name string) (string, bool) {
+	url, ok := licenseURLs[licenseKey(name)]
+	return url, ok
+}
+
+// licenseKey lower cases a license name and turns every run of characters other than letters, digits and dots into a
+// single space, so that "CC-BY 4.0" and "cc by 4.0" compare equal.
+func licenseKey(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.'
+	}), " ")
+}
+
+// checkLicenseURL returns an error if licenseURL is not the url of the named license. Unknown licenses and empty urls
+// pass.
+func checkLicenseURL(license string, licenseURL string) error {
+	expected, ok := LookupLicenseURL(license)
+	if !ok || licenseURL == "" || licenseURLKey(licenseURL) == licenseURLKey(expected) {
+		return nil
+	}
+	return errors.Err("license url '%s' is not the url of '%s', expected %s", licenseURL, license, expected)
+}
+
+// licenseURLKey strips what can differ between two urls of the same license text: the scheme, a www. prefix, the
+// legalcode page and a trailing slash.
+func licenseURLKey(url string) string {
+	key := strings.ToLower(strings.TrimSpace(url))
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	key = strings.TrimPrefix(key, "www.")
+	key = strings.TrimSuffix(key, "/")
+	key = strings.TrimSuffix(key, "/legalcode")
+	return strings.TrimSuffix(key, "/")
+}
@@ -0,0 +1,73 @@
+package stake
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestLookupLicenseURL(t *testing.T) {
+	tests := []struct {
+		Name string
+		URL  string
+	}{
+		{"Creative Commons Attribution 4.0 International", "https://creativecommons.org/licenses/by/4.0/"},
+		{"Creative Commons Attribution 4.0", "https://creativecommons.org/licenses/by/4.0/"},
+		{"CC-BY", "https://creativecommons.org/licenses/by/4.0/"},
+		{"cc by-sa 3.0", "https://creativecommons.org/licenses/by-sa/3.0/"},
+		{"Creative Commons Attribution-NonCommercial-NoDerivatives 4.0 International", "https://creativecommons.org/licenses/by-nc-nd/4.0/"},
+		{"Creative Commons Attribution-ShareAlike 3.0 Unported", "https://creativecommons.org/licenses/by-sa/3.0/"},
+		{" public domain ", "https://creativecommons.org/publicdomain/mark/1.0/"},
+		{"CC0 1.0 Universal", "https://creativecommons.org/publicdomain/zero/1.0/"},
+		{"Standard YouTube License", "https://www.youtube.com/t/terms"},
+	}
+	for _, test := range tests {
+		url, ok := LookupLicenseURL(test.Name)
+		assert.Assert(t, ok, test.Name)
+		assert.Equal(t, url, test.URL, test.Name)
+	}
+
+	for _, name := range []string{"", "Copyright", "None", "creative commons", "CC BY 5.0"} {
+		_, ok := LookupLicenseURL(name)
+		assert.Assert(t, !ok, name)
+	}
+}
+
+func TestBuilderLicenseURL(t *testing.T) {
+	build := func(license, licenseURL string) (string, error) {
+		claim, err := validBuilder().License(license, licenseURL).Build()
+		return claim.GetStream().GetLicenseUrl(), err
+	}
+
+	url, err := build("Creative Commons Attribution 4.0", "")
+	assert.NilError(t, err)
+	assert.Equal(t, url, "https://creativecommons.org/licenses/by/4.0/")
+
+	url, err = build("CC BY 4.0", "http://creativecommons.org/licenses/by/4.0/legalcode")
+	assert.NilError(t, err)
+	assert.Equal(t, url, "http://creativecommons.org/licenses/by/4.0/legalcode")
+
+	url, err = build("Copyright", "https://example.com/terms")
+	assert.NilError(t, err)
+	assert.Equal(t, url, "https://example.com/terms")
+
+	_, err = build("CC BY-SA 4.0", "https://creativecommons.org/licenses/by/4.0/")
+	assert.ErrorContains(t, err, "is not the url of 'CC BY-SA 4.0'")
+}
+
+func TestMigrateLicenseURL(t *testing.T) {
+	mismatched := []byte(`{"ver": "0.0.3", "title": "license", "license": "CC-BY", "license_url": "https://example.com", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`)
+	claim, err := MigrateFromJSONWithOptions(mismatched, MigrationOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetLicenseUrl(), "https://example.com")
+	_, err = MigrateFromJSONWithOptions(mismatched, MigrationOptions{Strict: true})
+	assert.ErrorContains(t, err, "is not the url of 'CC-BY'")
+
+	missing := []byte(`{"ver": "0.0.3", "title": "license", "license": "Creative Commons Attribution 4.0", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`)
+	for _, strict := range []bool{false, true} {
+		claim, warnings, err := MigrateFromJSONWithWarnings(missing, MigrationOptions{Strict: strict})
+		assert.NilError(t, err)
+		assert.Equal(t, claim.GetStream().GetLicenseUrl(), "https://creativecommons.org/licenses/by/4.0/")
+		assert.DeepEqual(t, warnings, []string{"license url: filled in https://creativecommons.org/licenses/by/4.0/ for 'Creative Commons Attribution 4.0'"})
+	}
+}
@@ -0,0 +1,88 @@
+package stake
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// The default limits, in bytes, of the free text fields of a claim. Validate rejects authors longer than
+// MaxAuthorLength, while a ClaimBuilder given its own Limits checks the author against those. The others only apply
+// to claims made with a ClaimBuilder.
+const (
+	MaxTitleLength       = 512
+	MaxAuthorLength      = 512
+	MaxDescriptionLength = 5000
+	MaxLicenseLength     = 512
+)
+
+// FieldLimits are the longest, in bytes, the free text fields of a claim can be. A limit of 0 leaves the field
+// unlimited.
+type FieldLimits struct {
+	Title       int
+	Author      int
+	Description int
+	License     int
+}
+
+// DefaultFieldLimits are the limits a ClaimBuilder applies unless it is given others with Limits.
+var DefaultFieldLimits = FieldLimits{
+	Title:       MaxTitleLength,
+	Author:      MaxAuthorLength,
+	Description: MaxDescriptionLength,
+	License:     MaxLicenseLength,
+}
+
+const ellipsis = "…"
+
+// applyFieldLimits checks the free text fields of a stream claim against limits. Fields that are too long are cut if
+// truncate is set, and a description of each cut is returned. Otherwise every field that is too long is listed in
+// the ValidationErrors returned.
+func applyFieldLimits(claim *pb.Claim, limits FieldLimits, truncate bool) ([]string, error) {
+	fields := []struct {
+		Name  string
+		Value *string
+		Limit int
+	}{
+		{"title", &claim.Title, limits.Title},
+		{"author", &claim.GetStream().Author, limits.Author},
+		{"description", &claim.Description, limits.Description},
+		{"license", &claim.GetStream().License, limits.License},
+	}
+
+	var truncated []string
+	var violations ValidationErrors
+	for _, field := range fields {
+		length := len(*field.Value)
+		if field.Limit <= 0 || length <= field.Limit {
+			continue
+		}
+		if !truncate {
+			violations = append(violations, errors.Base("%s is %d bytes, more than the limit of %d", field.Name, length, field.Limit))
+			continue
+		}
+		*field.Value = truncateString(*field.Value, field.Limit)
+		truncated = append(truncated, fmt.Sprintf("%s: truncated from %d to %d bytes", field.Name, length, len(*field.Value)))
+	}
+
+	if len(violations) > 0 {
+		return nil, errors.Err(violations)
+	}
+	return truncated, nil
+}
+
+// truncateString cuts s on a character boundary so that, with an ellipsis added, it is at most limit bytes. The
+// ellipsis is left out if limit is too short to fit it.
+func truncateString(s string, limit int) string {
+	suffix := ellipsis
+	if limit < len(ellipsis) {
+		suffix = ""
+	}
+	cut := limit - len(suffix)
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + suffix
+}
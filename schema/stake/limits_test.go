@@ -0,0 +1,91 @@
+package stake
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"gotest.tools/assert"
+)
+
+func TestFieldLimits(t *testing.T) {
+	builder := func() *ClaimBuilder {
+		return validBuilder().
+			Title(strings.Repeat("t", MaxTitleLength+1)).
+			Description(strings.Repeat("d", MaxDescriptionLength)).
+			License("license", "")
+	}
+
+	_, err := builder().Author(strings.Repeat("a", MaxAuthorLength+1)).Build()
+	violations, ok := errors.Unwrap(err).(ValidationErrors)
+	assert.Assert(t, ok, "expected ValidationErrors, got %v", err)
+	assert.Equal(t, len(violations), 2, err.Error())
+	assert.ErrorContains(t, err, "title is 513 bytes, more than the limit of 512")
+	assert.ErrorContains(t, err, "author is 513 bytes")
+
+	claim, err := builder().Limits(FieldLimits{Title: 1000, Description: 10}).Build()
+	assert.Assert(t, claim == nil)
+	assert.ErrorContains(t, err, "description is 5000 bytes, more than the limit of 10")
+
+	claim, err = builder().Limits(FieldLimits{}).Build()
+	assert.NilError(t, err)
+	assert.Equal(t, len(claim.GetTitle()), MaxTitleLength+1)
+}
+
+func TestFieldLimitsOverrideAuthor(t *testing.T) {
+	author := strings.Repeat("a", MaxAuthorLength+1)
+	builder := func() *ClaimBuilder {
+		return validBuilder().Author(author)
+	}
+
+	_, err := builder().Build()
+	assert.ErrorContains(t, err, "author is 513 bytes, more than the limit of 512")
+
+	claim, err := builder().Limits(FieldLimits{Author: 1000}).Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetAuthor(), author)
+
+	claim, err = builder().Limits(FieldLimits{}).Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetAuthor(), author)
+
+	_, err = builder().Limits(FieldLimits{Author: 100}).Build()
+	assert.ErrorContains(t, err, "author is 513 bytes, more than the limit of 100")
+
+	// Validate isn't given the builder's limits, so it holds the claim to MaxAuthorLength
+	assert.ErrorContains(t, Validate(claim), "author is longer than 512 bytes")
+}
+
+func TestTruncateFields(t *testing.T) {
+	claim, warnings, err := NewClaimBuilder().
+		Title(strings.Repeat("é", MaxTitleLength)).
+		Description("short").
+		SDHash(testSDHash).
+		ContentType("video/mp4").
+		Truncate().
+		BuildWithWarnings()
+	assert.NilError(t, err)
+	assert.Assert(t, len(claim.GetTitle()) <= MaxTitleLength)
+	assert.Assert(t, utf8.ValidString(claim.GetTitle()))
+	assert.Assert(t, strings.HasSuffix(claim.GetTitle(), "…"))
+	assert.Equal(t, claim.GetDescription(), "short")
+	assert.DeepEqual(t, warnings, []string{"title: truncated from 1024 to 511 bytes"})
+
+	assert.Equal(t, truncateString("abcdef", 5), "ab…")
+	assert.Equal(t, truncateString("abcdef", 2), "ab")
+	assert.Equal(t, truncateString("abcdef", 1), "a")
+
+	// a limit too short for the ellipsis still keeps what fits of the title
+	claim, err = validBuilder().Limits(FieldLimits{Title: 2}).Truncate().Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetTitle(), "ti")
+}
+
+func TestMigrationIgnoresFieldLimits(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "long", "description": "` + strings.Repeat("d", MaxDescriptionLength+1) + `", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`)
+	claim, err := MigrateFromJSON(value)
+	assert.NilError(t, err)
+	assert.Equal(t, len(claim.GetDescription()), MaxDescriptionLength+1)
+}
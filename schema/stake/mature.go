@@ -0,0 +1,28 @@
+package stake
+
+import (
+	"strings"
+
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// matureTag is how the v2 schema marks what the legacy schemas flag as nsfw.
+const matureTag = "mature"
+
+// IsMature reports whether the claim is marked as mature content. Claims migrated from the legacy schemas carry their
+// nsfw flag as the mature tag, so this answers for both. The tag is matched case insensitively.
+func IsMature(claim *pb.Claim) bool {
+	for _, tag := range claim.GetTags() {
+		if strings.EqualFold(strings.TrimSpace(tag), matureTag) {
+			return true
+		}
+	}
+	return false
+}
+
+// setMature adds the mature tag to the claim, unless it already has it.
+func setMature(claim *pb.Claim) {
+	if !IsMature(claim) {
+		claim.Tags = append(claim.Tags, matureTag)
+	}
+}
@@ -0,0 +1,59 @@
+package stake
+
+import (
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestIsMature(t *testing.T) {
+	tests := []struct {
+		Tags   []string
+		Mature bool
+	}{
+		{nil, false},
+		{[]string{"music"}, false},
+		{[]string{"immature"}, false},
+		{[]string{"mature"}, true},
+		{[]string{"music", "Mature"}, true},
+		{[]string{" MATURE "}, true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, IsMature(&pb.Claim{Tags: test.Tags}), test.Mature, test.Tags)
+	}
+	assert.Assert(t, !IsMature(nil))
+}
+
+func TestMatureTagWithoutFlag(t *testing.T) {
+	claim := validStreamClaim(t)
+	claim.Tags = []string{"documentary", "Mature"}
+	assert.Assert(t, IsMature(claim))
+
+	v3Claim, err := toV3Claim(claim)
+	assert.NilError(t, err)
+	assert.Assert(t, v3Claim.NSFW)
+
+	setMature(claim)
+	assert.DeepEqual(t, claim.GetTags(), []string{"documentary", "Mature"})
+}
+
+func TestNSFWFlagWithoutTag(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "nsfw", "nsfw": true, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`)
+	claim, err := MigrateFromJSON(value)
+	assert.NilError(t, err)
+	assert.Assert(t, IsMature(claim))
+	assert.DeepEqual(t, claim.GetTags(), []string{"mature"})
+
+	v3JSON, err := ToV3JSON(claim)
+	assert.NilError(t, err)
+	roundTrip, err := MigrateFromJSON(v3JSON)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, roundTrip.GetTags(), []string{"mature"})
+
+	claim, err = MigrateFromJSON([]byte(`{"ver": "0.0.3", "title": "safe", "nsfw": false, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`))
+	assert.NilError(t, err)
+	assert.Assert(t, !IsMature(claim))
+	assert.Assert(t, claim.GetTags() == nil)
+}
@@ -2,12 +2,14 @@ package stake
 
 import (
 	"encoding/hex"
+	"fmt"
 
 	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/schema/address"
 	v1pb "github.com/lbryio/types/v1/go"
 	pb "github.com/lbryio/types/v2/go"
 
-	"github.com/btcsuite/btcutil/base58"
+	log "github.com/sirupsen/logrus"
 )
 
 const lbrySDHash = "lbry_sd_hash"
@@ -34,28 +36,6 @@ func newChannelClaim() *pb.Claim {
 	return pbClaim
 }
 
-func setMetaData(claim *pb.Claim, author string, description string, language pb.Language_Language, license string,
-	licenseURL *string, title string, thumbnail *string, nsfw bool) {
-	claim.Title = title
-	claim.Description = description
-
-	claim.GetStream().Author = author
-	claim.Languages = []*pb.Language{{Language: language}}
-
-	if thumbnail != nil {
-		source := new(pb.Source)
-		source.Url = *thumbnail
-		claim.Thumbnail = source
-	}
-	if nsfw {
-		claim.Tags = []string{"mature"}
-	}
-	claim.GetStream().License = license
-	if licenseURL != nil {
-		claim.GetStream().LicenseUrl = *licenseURL
-	}
-}
-
 func migrateV1PBClaim(vClaim v1pb.Claim) (*pb.Claim, error) {
 	if *vClaim.ClaimType == v1pb.Claim_streamType {
 		return migrateV1PBStream(vClaim)
@@ -75,12 +55,12 @@ func migrateV1PBStream(vClaim v1pb.Claim) (*pb.Claim, error) {
 	md := vClaim.GetStream().GetMetadata()
 	if md.GetFee() != nil {
 		claim.GetStream().Fee = new(pb.Fee)
-		claim.GetStream().GetFee().Amount = uint64(*md.GetFee().Amount * 100000000)
-		claim.GetStream().GetFee().Address = md.GetFee().GetAddress()
 		claim.GetStream().GetFee().Currency = pb.Fee_Currency(pb.Fee_Currency_value[md.GetFee().GetCurrency().String()])
+		claim.GetStream().GetFee().Amount = feeAmount(claim.GetStream().GetFee().GetCurrency(), md.GetFee().GetAmount())
+		claim.GetStream().GetFee().Address = md.GetFee().GetAddress()
 	}
 	if vClaim.GetStream().GetMetadata().GetNsfw() {
-		claim.Tags = []string{"mature"}
+		setMature(claim)
 	}
 	thumbnailSource := new(pb.Source)
 	thumbnailSource.Url = md.GetThumbnail()
@@ -103,95 +83,265 @@ func migrateV1PBChannel(vClaim v1pb.Claim) (*pb.Claim, error) {
 	return claim, nil
 }
 
-func migrateV1Claim(vClaim V1Claim) (*pb.Claim, error) {
-	pbClaim := newStreamClaim()
-	//Stream
-	// -->Universal
-	setFee(vClaim.Fee, pbClaim)
-	// -->MetaData
-	language := pb.Language_Language(pb.Language_Language_value[vClaim.Language])
-	setMetaData(pbClaim, vClaim.Author, vClaim.Description, language,
-		vClaim.License, nil, vClaim.Title, vClaim.Thumbnail, false)
-	// -->Source
-	source := new(pb.Source)
-	source.MediaType = vClaim.ContentType
+func migrateV1Claim(vClaim V1Claim, opts MigrationOptions) (*pb.Claim, []string, error) {
+	language, warnings, err := migrateLanguage(vClaim.Language, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	claim, fixes, err := newMigrationBuilder(opts).
+		Title(vClaim.Title).
+		Description(vClaim.Description).
+		Author(vClaim.Author).
+		Language(language).
+		License(vClaim.License, "").
+		Thumbnail(stringValue(vClaim.Thumbnail)).
+		NSFW(vClaim.NSFW).
+		jsonFee(vClaim.Fee).
+		SDHash(vClaim.Sources.LbrySDHash).
+		ipfsSource(vClaim.Sources.IPFSCID).
+		ContentType(vClaim.ContentType).
+		BuildWithWarnings()
+	if err != nil {
+		return nil, nil, err
+	}
+	return claim, append(warnings, fixes...), nil
+}
+
+func migrateV2Claim(vClaim V2Claim, opts MigrationOptions) (*pb.Claim, []string, error) {
+	language, warnings, err := migrateLanguage(vClaim.Language, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	claim, fixes, err := newMigrationBuilder(opts).
+		Title(vClaim.Title).
+		Description(vClaim.Description).
+		Author(vClaim.Author).
+		Language(language).
+		License(vClaim.License, stringValue(vClaim.LicenseURL)).
+		Thumbnail(stringValue(vClaim.Thumbnail)).
+		NSFW(vClaim.NSFW).
+		jsonFee(vClaim.Fee).
+		SDHash(vClaim.Sources.LbrySDHash).
+		ipfsSource(vClaim.Sources.IPFSCID).
+		ContentType(vClaim.ContentType).
+		BuildWithWarnings()
+	if err != nil {
+		return nil, nil, err
+	}
+	return claim, append(warnings, fixes...), nil
+}
 
-	src, err := hex.DecodeString(vClaim.Sources.LbrySDHash)
+func migrateV3Claim(vClaim V3Claim, opts MigrationOptions) (*pb.Claim, []string, error) {
+	language, warnings, err := migrateLanguage(vClaim.Language, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	claim, fixes, err := newMigrationBuilder(opts).
+		Title(vClaim.Title).
+		Description(vClaim.Description).
+		Author(vClaim.Author).
+		Language(language).
+		License(vClaim.License, stringValue(vClaim.LicenseURL)).
+		Thumbnail(stringValue(vClaim.Thumbnail)).
+		NSFW(vClaim.NSFW).
+		jsonFee(vClaim.Fee).
+		SDHash(vClaim.Sources.LbrySDHash).
+		ipfsSource(vClaim.Sources.IPFSCID).
+		ContentType(vClaim.ContentType).
+		BuildWithWarnings()
 	if err != nil {
-		return nil, errors.Err(err)
+		return nil, nil, err
 	}
-	source.SdHash = src
-	pbClaim.GetStream().Source = source
+	return claim, append(warnings, fixes...), nil
+}
 
-	return pbClaim, nil
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
 }
 
-func migrateV2Claim(vClaim V2Claim) (*pb.Claim, error) {
-	pbClaim := newStreamClaim()
-	//Stream
-	// -->Fee
-	setFee(vClaim.Fee, pbClaim)
-	// -->MetaData
-	language := pb.Language_Language(pb.Language_Language_value[vClaim.Language])
-	setMetaData(pbClaim, vClaim.Author, vClaim.Description, language,
-		vClaim.License, vClaim.LicenseURL, vClaim.Title, vClaim.Thumbnail, vClaim.NSFW)
-	// -->Source
-	source := new(pb.Source)
-	source.MediaType = vClaim.ContentType
-	src, err := hex.DecodeString(vClaim.Sources.LbrySDHash)
+// ErrUnknownClaimVersion is returned when a json claim does not carry a version that can be migrated.
+type ErrUnknownClaimVersion struct {
+	Version string
+}
+
+func (e ErrUnknownClaimVersion) Error() string {
+	return fmt.Sprintf("unknown claim version '%s'", e.Version)
+}
+
+// MigrationOptions controls how forgiving the migration of json claims is. The zero value is lenient.
+type MigrationOptions struct {
+	// Strict fails the migration with ErrUnknownLanguage when a claim's language can't be resolved, instead of
+	// migrating the claim without a language and a warning. It also fails claims whose thumbnail doesn't pass
+	// NormalizeThumbnailURL, which are otherwise migrated with the thumbnail as is and a warning, and claims whose
+	// content type fails NormalizeContentType, which are otherwise migrated with it as is, or as
+	// application/octet-stream if it isn't a media type at all, and a warning. Claims whose license url isn't the one
+	// LookupLicenseURL has for their license fail as well.
+	Strict bool
+	// AllowMalformedSDHash migrates claims whose sd hash fails ValidateSDHash, for archiving claims that can never
+	// resolve a stream. The hash is kept as decoded hex, or as the raw string if it isn't hex, and a warning.
+	AllowMalformedSDHash bool
+	// BlockchainName is the network fee addresses are checked against, lbrycrd_main if empty.
+	BlockchainName string
+}
+
+func (o MigrationOptions) blockchainName() string {
+	if o.BlockchainName == "" {
+		return "lbrycrd_main"
+	}
+	return o.BlockchainName
+}
+
+// MigrateFromJSON migrates a json claim of any of the historical versions (V1, V2 or V3) to a protobuf claim. The
+// version is detected with DetectVersion, so V1 claims that left out the "ver" field are migrated as well.
+func MigrateFromJSON(value []byte) (*pb.Claim, error) {
+	return MigrateFromJSONWithOptions(value, MigrationOptions{})
+}
+
+// MigrateFromJSONWithOptions works like MigrateFromJSON, with opts deciding how problems in the claim are handled.
+// Fixes applied to sloppy claims are logged as warnings.
+func MigrateFromJSONWithOptions(value []byte, opts MigrationOptions) (*pb.Claim, error) {
+	claim, warnings, err := MigrateFromJSONWithWarnings(value, opts)
+	for _, warning := range warnings {
+		log.Warnf("claim '%s': %s", claim.GetTitle(), warning)
+	}
+	return claim, err
+}
+
+// MigrateFromJSONWithWarnings works like MigrateFromJSONWithOptions, returning the fixes applied to the claim instead
+// of logging them: those V1Claim.UnmarshalTolerant made to a V1 claim, a language that was left out, a malformed sd
+// hash or thumbnail that was kept as is, and any fix to the content type or license url.
+func MigrateFromJSONWithWarnings(value []byte, opts MigrationOptions) (*pb.Claim, []string, error) {
+	version, rawVersion, err := detectVersion(value)
 	if err != nil {
-		return nil, errors.Err(err)
+		return nil, nil, err
 	}
-	source.SdHash = src
-	pbClaim.GetStream().Source = source
 
-	return pbClaim, nil
+	switch version {
+	case VersionV1:
+		v1Claim := new(V1Claim)
+		warnings, err := v1Claim.UnmarshalTolerant(value)
+		if err != nil {
+			return nil, nil, errors.Prefix("V1 Metadata Migration Error", err)
+		}
+		claim, fixes, err := migrateV1Claim(*v1Claim, opts)
+		if err != nil {
+			return nil, nil, errors.Prefix("V1 Metadata Migration Error", err)
+		}
+		return claim, append(warnings, fixes...), nil
+	case VersionV2:
+		v2Claim := new(V2Claim)
+		err = v2Claim.Unmarshal(value)
+		if err != nil {
+			return nil, nil, errors.Prefix("V2 Metadata Migration Error", err)
+		}
+		claim, warnings, err := migrateV2Claim(*v2Claim, opts)
+		if err != nil {
+			return nil, nil, errors.Prefix("V2 Metadata Migration Error", err)
+		}
+		return claim, warnings, nil
+	case VersionV3:
+		v3Claim := new(V3Claim)
+		err = v3Claim.Unmarshal(value)
+		if err != nil {
+			return nil, nil, errors.Prefix("V3 Metadata Migration Error", err)
+		}
+		claim, warnings, err := migrateV3Claim(*v3Claim, opts)
+		if err != nil {
+			return nil, nil, errors.Prefix("V3 Metadata Migration Error", err)
+		}
+		return claim, warnings, nil
+	}
+
+	return nil, nil, errors.Err(ErrUnknownClaimVersion{Version: rawVersion})
 }
 
-func migrateV3Claim(vClaim V3Claim) (*pb.Claim, error) {
-	pbClaim := newStreamClaim()
-	//Stream
-	// -->Fee
-	setFee(vClaim.Fee, pbClaim)
-	// -->MetaData
-	language := pb.Language_Language(pb.Language_Language_value[vClaim.Language])
-	setMetaData(pbClaim, vClaim.Author, vClaim.Description, language,
-		vClaim.License, vClaim.LicenseURL, vClaim.Title, vClaim.Thumbnail, vClaim.NSFW)
-	// -->Source
-	source := new(pb.Source)
-	source.MediaType = vClaim.ContentType
-	src, err := hex.DecodeString(vClaim.Sources.LbrySDHash)
+// migrateLanguage resolves the language of a json claim. Unless opts is strict, a language that can't be resolved is
+// left out and a warning is returned.
+func migrateLanguage(raw string, opts MigrationOptions) (pb.Language_Language, []string, error) {
+	language, err := ResolveLanguage(raw)
+	if err != nil {
+		if opts.Strict {
+			return language, nil, err
+		}
+		return language, []string{fmt.Sprintf("%s, migrating claim without a language", err.Error())}, nil
+	}
+	return language, nil, nil
+}
+
+// decodeSDHash decodes the sd hash of a claim. If opts allows malformed sd hashes, one that fails ValidateSDHash is
+// kept as is and a warning is returned.
+func decodeSDHash(sdHash string, title string, opts MigrationOptions) ([]byte, []string, error) {
+	err := ValidateSDHash(sdHash)
+	if err == nil {
+		decoded, err := hex.DecodeString(sdHash)
+		return decoded, nil, err
+	}
+	if !opts.AllowMalformedSDHash {
+		return nil, nil, errors.Prefix(fmt.Sprintf("claim '%s'", title), err)
+	}
+
+	warnings := []string{fmt.Sprintf("%s, keeping it as is", err.Error())}
+	decoded, decodeErr := hex.DecodeString(sdHash)
+	if decodeErr != nil {
+		return []byte(sdHash), warnings, nil
+	}
+	return decoded, warnings, nil
+}
+
+// normalizeThumbnail normalizes the thumbnail with NormalizeThumbnailURL. If lenient, a thumbnail that fails is kept
+// as is and a warning is returned.
+func normalizeThumbnail(thumbnail string, title string, lenient bool) (string, []string, error) {
+	normalized, err := NormalizeThumbnailURL(thumbnail)
+	if err == nil {
+		return normalized, nil, nil
+	}
+	if !lenient {
+		return "", nil, errors.Prefix(fmt.Sprintf("claim '%s'", title), err)
+	}
+
+	return thumbnail, []string{fmt.Sprintf("%s, keeping it as is", err.Error())}, nil
+}
+
+// decodeFeeAddress decodes a fee address and checks that it is for the network of blockchainName.
+func decodeFeeAddress(feeAddress string, blockchainName string) ([addressLength]byte, error) {
+	network, err := address.NetworkFor(blockchainName)
 	if err != nil {
-		return nil, errors.Err(err)
-	}
-	source.SdHash = src
-	pbClaim.GetStream().Source = source
-
-	return pbClaim, nil
-}
-
-func setFee(fee *Fee, pbClaim *pb.Claim) {
-	if fee != nil {
-		amount := float32(0.0)
-		currency := pb.Fee_LBC
-		address := ""
-		if fee.BTC != nil {
-			amount = fee.BTC.Amount
-			currency = pb.Fee_BTC
-			address = fee.BTC.Address
-		} else if fee.LBC != nil {
-			amount = fee.LBC.Amount
-			currency = pb.Fee_LBC
-			address = fee.LBC.Address
-		} else if fee.USD != nil {
-			amount = fee.USD.Amount
-			currency = pb.Fee_USD
-			address = fee.USD.Address
+		return [addressLength]byte{}, err
+	}
+	decoded, err := address.Decode(feeAddress)
+	if err != nil {
+		return [addressLength]byte{}, err
+	}
+	if decoded.Network != network {
+		return [addressLength]byte{}, errors.Err("%s address on %s", decoded.Network, blockchainName)
+	}
+	return decoded.Bytes()
+}
+
+// setFee sets the claim's fee, checking the fee address against the version bytes and checksum of blockchainName. A
+// fee must be in a single currency, and may only leave out the address if its amount is zero.
+func setFee(fee *Fee, pbClaim *pb.Claim, blockchainName string) error {
+	if fee == nil {
+		return nil
+	}
+	currency, info, err := fee.currency()
+	if err != nil {
+		return err
+	}
+
+	pbClaim.GetStream().Fee = new(pb.Fee)
+	//Fee Settings
+	pbClaim.GetStream().GetFee().Amount = feeAmount(currency, info.Amount)
+	pbClaim.GetStream().GetFee().Currency = currency
+	if info.Address != "" {
+		decoded, err := decodeFeeAddress(info.Address, blockchainName)
+		if err != nil {
+			return errors.Prefix(fmt.Sprintf("invalid fee address '%s'", info.Address), err)
 		}
-		pbClaim.GetStream().Fee = new(pb.Fee)
-		//Fee Settings
-		pbClaim.GetStream().GetFee().Amount = uint64(amount * 100000000)
-		pbClaim.GetStream().GetFee().Currency = currency
-		pbClaim.GetStream().GetFee().Address = base58.Decode(address)
+		pbClaim.GetStream().GetFee().Address = decoded[:]
 	}
+	return nil
 }
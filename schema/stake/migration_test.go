@@ -3,12 +3,16 @@ package stake
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/lbryio/lbry.go/v2/extras/errors"
 	"github.com/lbryio/lbry.go/v2/schema/address"
+	pb "github.com/lbryio/types/v2/go"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/golang/protobuf/proto"
 	"gotest.tools/assert"
 )
 
@@ -55,7 +59,7 @@ var jsonVersionTests = []valueTestPair{
 			"LBC",
 			"bPwGA9h7uijoy5uAvzVPQw9QyLoYZehHJo",
 			"application/octet-stream",
-			"", //"English" is not supported for conversion.
+			"language:en ",
 			"bd94033d13f4f3908708701caf565bfa09cfadf2f34fadf4a73fb86b295d1b21a7e64805994e45b5fbc650f30bac4874",
 			"/homerobert/lbry/speed.jpg",
 			false,
@@ -203,7 +207,7 @@ func TestMigrationFromJSON(t *testing.T) {
 		if helper.Claim.GetStream().GetLicenseUrl() != pair.Claim.LicenseURL {
 			t.Error("LicenseURL mismatch: expected", pair.Claim.LicenseURL, "got", helper.Claim.GetStream().GetLicenseUrl())
 		}
-		if helper.Claim.GetStream().GetFee().GetAmount() != uint64(pair.Claim.FeeAmount*100000000) {
+		if helper.Claim.GetStream().GetFee().GetAmount() != feeAmount(helper.Claim.GetStream().GetFee().GetCurrency(), pair.Claim.FeeAmount) {
 			t.Error("Fee Amount mismatch: expected", pair.Claim.FeeAmount, "got", helper.Claim.GetStream().GetFee().GetAmount())
 		}
 		if helper.Claim.GetStream().GetFee().GetCurrency().String() != pair.Claim.FeeCurrency {
@@ -280,3 +284,324 @@ func TestMigrationFromV1UnsignedWithFee(t *testing.T) {
 	assert.Assert(t, claim.GetStream().GetFee().GetCurrency().String() == "LBC")
 
 }
+
+var migrateFromJSONTests = []struct {
+	JSON      string
+	Title     string
+	FeeAmount uint64
+}{
+	// V1 without a version and an integer fee amount
+	{`{"fee": {"LBC": {"amount": 2, "address": "bPwGA9h7uijoy5uAvzVPQw9QyLoYZehHJo"}}, "description": "d", "license": "None", "author": "root", "language": "en", "title": "v1 no version", "sources": {"lbry_sd_hash": "bd94033d13f4f3908708701caf565bfa09cfadf2f34fadf4a73fb86b295d1b21a7e64805994e45b5fbc650f30bac4874"}, "content-type": "application/octet-stream"}`,
+		"v1 no version", 200000000},
+	// V1 with an explicit version and an integer fee amount
+	{`{"ver": "0.0.1", "fee": {"LBC": {"amount": 50, "address": "bLVs3ifPruyZnpYmFfT2TLAmhqZvgjpQDa"}}, "description": "d", "license": "None", "author": "root", "language": "en", "title": "v1 with version", "sources": {"lbry_sd_hash": "dcc1bf28893a5037eab9e4a9cd7a4bfe6f76ad6c21970ea4ceee0122f502ef079657d4dca456b4be3249849c4e1868b8"}, "content-type": "video/quicktime"}`,
+		"v1 with version", 5000000000},
+	{`{"ver": "0.0.2", "language": "en", "fee": {"USD": {"amount": 0.01, "address": "bMHmZKZbPq6bPBEQFc8MXpiDhF9f7MVxMR"}}, "sources": {"lbry_sd_hash": "2bd8d9dd1a218c7f56717e53fa510efd5a8c089ed1f2675a0f8d0b5b8bb3c1ed383cb9f3aeb9b891789761305293979a"}, "description": "clouds", "license": "creative commons", "author": "a", "nsfw": false, "title": "v2", "content-type": "video/mp4"}`,
+		"v2", 1},
+	{`{"ver": "0.0.3", "license": "Copyright", "language": "en", "title": "v3", "author": "a", "sources": {"lbry_sd_hash": "fc0dac5cfc526354963ff1769f6e739c4e42a0790420ffab9fa3b6401e93ae5a0515eff960f8c9c272907eda6fcba254"}, "nsfw": true, "content_type": "video/mp4", "description": "d"}`,
+		"v3", 0},
+}
+
+var unknownVersionJSONTests = []struct {
+	JSON    string
+	Version string
+}{
+	{`{"ver": "0.0.4", "title": "from the future", "sources": {"lbry_sd_hash": ""}}`, "0.0.4"},
+	{`{"ver": 3, "title": "numeric version", "sources": {"lbry_sd_hash": ""}}`, "3"},
+	{`{"title": "no version and no sources"}`, ""},
+}
+
+func TestMigrateFromJSON(t *testing.T) {
+	for _, test := range migrateFromJSONTests {
+		claim, err := MigrateFromJSON([]byte(test.JSON))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		assert.Equal(t, claim.GetTitle(), test.Title)
+		assert.Equal(t, claim.GetStream().GetFee().GetAmount(), test.FeeAmount)
+		assert.Equal(t, len(claim.GetStream().GetSource().GetSdHash()), 48)
+	}
+
+	for _, pair := range jsonVersionTests {
+		valueBytes, err := hex.DecodeString(pair.ValueAsHex)
+		if err != nil {
+			t.Error(err)
+		}
+		claim, err := MigrateFromJSON(valueBytes)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		assert.Equal(t, claim.GetTitle(), pair.Claim.Title)
+	}
+}
+
+func TestMigrateFromJSONUnknownVersion(t *testing.T) {
+	for _, test := range unknownVersionJSONTests {
+		_, err := MigrateFromJSON([]byte(test.JSON))
+		if err == nil {
+			t.Errorf("expected an error migrating %s", test.JSON)
+			continue
+		}
+		versionErr, ok := errors.Unwrap(err).(ErrUnknownClaimVersion)
+		if !ok {
+			t.Errorf("expected ErrUnknownClaimVersion, got %v", err)
+			continue
+		}
+		assert.Equal(t, versionErr.Version, test.Version)
+	}
+
+	_, err := MigrateFromJSON([]byte("not json"))
+	assert.Assert(t, err != nil)
+}
+
+func TestSetFee(t *testing.T) {
+	tests := []struct {
+		Amount         float32
+		Address        string
+		BlockchainName string
+		Valid          bool
+	}{
+		{1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6", "lbrycrd_main", true},
+		{1, "bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN", "lbrycrd_main", true},
+		{1, "r6stj4JjLFtCmynDqDVjmkDXS9zfJa56qc", "lbrycrd_main", true},
+		{1, "mgAFts2ZXGnZnDFGZbqESNDsWcAHVZMVjM", "lbrycrd_testnet", true},
+		{1, "2MstXb6N3scB3J46deG9Kf2Mk1V4Tsu2egZ", "lbrycrd_testnet", true},
+		{1, "mgAFts2ZXGnZnDFGZbqESNDsWcAHVZMVjM", "lbrycrd_regtest", true},
+		{0, "", "lbrycrd_main", true},
+		{0, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6", "lbrycrd_main", true},
+		// corrupted checksum
+		{1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP7", "lbrycrd_main", false},
+		// typo in the middle
+		{1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb69utjP6", "lbrycrd_main", false},
+		// wrong network
+		{1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6", "lbrycrd_testnet", false},
+		{1, "mgAFts2ZXGnZnDFGZbqESNDsWcAHVZMVjM", "lbrycrd_main", false},
+		// bitcoin address
+		{1, "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "lbrycrd_main", false},
+		// not base58
+		{1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP0", "lbrycrd_main", false},
+		{1, "", "lbrycrd_main", false},
+		{1, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6", "not_a_chain", false},
+	}
+
+	for _, test := range tests {
+		claim := newStreamClaim()
+		fee := &Fee{"LBC": &FeeInfo{Amount: test.Amount, Address: test.Address}}
+		err := setFee(fee, claim, test.BlockchainName)
+		if test.Valid {
+			assert.NilError(t, err, test.Address)
+			if test.Address != "" {
+				var decoded [25]byte
+				copy(decoded[:], claim.GetStream().GetFee().GetAddress())
+				encoded, err := address.EncodeAddress(decoded, test.BlockchainName)
+				assert.NilError(t, err)
+				assert.Equal(t, encoded, test.Address)
+			}
+		} else {
+			assert.Assert(t, err != nil, "%s on %s", test.Address, test.BlockchainName)
+		}
+	}
+
+	err := setFee(&Fee{"LBC": &FeeInfo{Amount: 1, Address: "mgAFts2ZXGnZnDFGZbqESNDsWcAHVZMVjM"}}, newStreamClaim(), "lbrycrd_main")
+	assert.ErrorContains(t, err, "testnet address on lbrycrd_main")
+}
+
+// multiCurrencyFeeTests are shaped like historical claims whose fee names more than one currency.
+var multiCurrencyFeeTests = []string{
+	`{"title": "lbc and usd", "description": "", "author": "", "language": "en", "license": "", "content-type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"LBC": {"amount": 10, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}, "USD": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`,
+	`{"ver": "0.0.2", "title": "btc and lbc", "language": "en", "content_type": "video/mp4", "license_url": "", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"BTC": {"amount": 0.001, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}, "LBC": {"amount": 5, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`,
+	`{"ver": "0.0.3", "title": "all three", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"LBC": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}, "BTC": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}, "USD": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`,
+}
+
+func TestMultiCurrencyFee(t *testing.T) {
+	for _, test := range multiCurrencyFeeTests {
+		_, err := MigrateFromJSON([]byte(test))
+		assert.ErrorContains(t, err, "more than one currency")
+		_, err = DecodeClaimBytes([]byte(test), "lbrycrd_main")
+		assert.ErrorContains(t, err, "more than one currency")
+	}
+
+	lbc := &FeeInfo{Amount: 1, Address: "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}
+	err := setFee(&Fee{"LBC": lbc, "USD": lbc}, newStreamClaim(), "lbrycrd_main")
+	assert.ErrorContains(t, err, "more than one currency (LBC and USD)")
+}
+
+func TestInvalidFee(t *testing.T) {
+	tests := map[string]Fee{
+		"is negative":    {"USD": &FeeInfo{Amount: -1, Address: "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}},
+		"has no address": {"BTC": &FeeInfo{Amount: 0.5}},
+	}
+	for message, fee := range tests {
+		err := setFee(&fee, newStreamClaim(), "lbrycrd_main")
+		assert.ErrorContains(t, err, message)
+
+		value, err := json.Marshal(V3Claim{Version: "0.0.3", Title: "fee", Fee: &fee})
+		assert.NilError(t, err)
+		_, err = MigrateFromJSON(value)
+		assert.ErrorContains(t, err, message)
+	}
+
+	claim := newStreamClaim()
+	assert.NilError(t, setFee(&Fee{}, claim, "lbrycrd_main"))
+	assert.Equal(t, claim.GetStream().GetFee().GetCurrency(), pb.Fee_LBC)
+}
+
+func TestUnknownFeeCurrency(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "euros", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"EUR": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`)
+	_, err := MigrateFromJSON(value)
+	currencyErr, ok := errors.Unwrap(err).(ErrUnknownCurrency)
+	assert.Assert(t, ok, err)
+	assert.Equal(t, currencyErr.Currency, "EUR")
+
+	err = setFee(&Fee{"EUR": &FeeInfo{Amount: 1, Address: "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}, newStreamClaim(), "lbrycrd_main")
+	_, ok = errors.Unwrap(err).(ErrUnknownCurrency)
+	assert.Assert(t, ok, err)
+
+	assert.DeepEqual(t, SupportedCurrencies(), []string{"BTC", "LBC", "USD"})
+}
+
+func TestAddFeeCurrency(t *testing.T) {
+	eur := pb.Fee_Currency(len(pb.Fee_Currency_name))
+	FeeCurrencies["EUR"] = eur
+	defer delete(FeeCurrencies, "EUR")
+	FeePrecisions[eur] = 100
+	defer delete(FeePrecisions, eur)
+
+	value := []byte(`{"ver": "0.0.3", "title": "euros", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"EUR": {"amount": 1.5, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`)
+	claim, err := MigrateFromJSON(value)
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetStream().GetFee().GetCurrency(), eur)
+	assert.Equal(t, claim.GetStream().GetFee().GetAmount(), uint64(150))
+
+	v3Claim, err := toV3Claim(claim)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, *v3Claim.Fee, Fee{"EUR": &FeeInfo{Amount: 1.5, Address: "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}})
+
+	built, err := validBuilder().Title("euros").Fee(eur, 1.5, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6").Build()
+	assert.NilError(t, err)
+	assert.Assert(t, Equal(built, claim))
+}
+
+var roundTripTests = []struct {
+	Name  string
+	JSON  string
+	Claim claimResult
+}{
+	{"v1 without a fee",
+		`{"ver": "0.0.1", "title": "v1", "description": "no fee", "author": "someone", "language": "en", "license": "Public Domain", "content-type": "video/mp4", "thumbnail": "https://example.com/v1.jpg", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		claimResult{"0.0.1", "someone", "v1", "no fee", "Public Domain", "https://creativecommons.org/publicdomain/mark/1.0/", 0, "", "", "video/mp4", "language:en ", testSDHash, "https://example.com/v1.jpg", false}},
+	{"v1 with a fee",
+		`{"title": "v1 fee", "description": "", "author": "", "language": "en-US", "license": "", "content-type": "audio/mpeg", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"LBC": {"amount": 2.5, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`,
+		claimResult{"", "", "v1 fee", "", "", "", 2.5, "LBC", "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6", "audio/mpeg", "language:en ", testSDHash, "", false}},
+	{"v2 in french with an empty thumbnail",
+		`{"ver": "0.0.2", "title": "v2", "description": "une description", "author": "quelqu'un", "language": "French", "license": "CC-BY", "license_url": "https://creativecommons.org/licenses/by/4.0/", "content-type": "video/webm", "thumbnail": "", "nsfw": true, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"USD": {"amount": 0.5, "address": "bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN"}}}`,
+		claimResult{"0.0.2", "quelqu'un", "v2", "une description", "CC-BY", "https://creativecommons.org/licenses/by/4.0/", 0.5, "USD", "bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN", "video/webm", "language:fr ", testSDHash, "", true}},
+	{"v3 in japanese with a btc fee",
+		`{"ver": "0.0.3", "title": "v3", "description": "説明", "author": "誰か", "language": "ja", "license": "", "content_type": "image/png", "thumbnail": "https://example.com/v3.png", "nsfw": false, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"BTC": {"amount": 0.001, "address": "r6stj4JjLFtCmynDqDVjmkDXS9zfJa56qc"}}}`,
+		claimResult{"0.0.3", "誰か", "v3", "説明", "", "", 0.001, "BTC", "r6stj4JjLFtCmynDqDVjmkDXS9zfJa56qc", "image/png", "language:ja ", testSDHash, "https://example.com/v3.png", false}},
+	{"v3 without a fee or language",
+		`{"ver": "0.0.3", "title": "v3 free", "description": "", "author": "", "language": "", "license": "", "content_type": "application/pdf", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		claimResult{"0.0.3", "", "v3 free", "", "", "", 0, "", "", "application/pdf", "", testSDHash, "", false}},
+}
+
+func migrateFixture(value []byte) (*pb.Claim, error) {
+	version, err := DetectVersion(value)
+	if err != nil {
+		return nil, err
+	}
+	switch version {
+	case VersionV1:
+		var vClaim V1Claim
+		if err := vClaim.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		claim, _, err := migrateV1Claim(vClaim, MigrationOptions{})
+		return claim, err
+	case VersionV2:
+		var vClaim V2Claim
+		if err := vClaim.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		claim, _, err := migrateV2Claim(vClaim, MigrationOptions{})
+		return claim, err
+	case VersionV3:
+		var vClaim V3Claim
+		if err := vClaim.Unmarshal(value); err != nil {
+			return nil, err
+		}
+		claim, _, err := migrateV3Claim(vClaim, MigrationOptions{})
+		return claim, err
+	}
+	return nil, errors.Err("fixture has version %s", version)
+}
+
+func TestMigrationRoundTrip(t *testing.T) {
+	for _, test := range roundTripTests {
+		migrated, err := migrateFixture([]byte(test.JSON))
+		if err != nil {
+			t.Error(test.Name, err)
+			continue
+		}
+		serialized, err := proto.Marshal(migrated)
+		if err != nil {
+			t.Error(test.Name, err)
+			continue
+		}
+		claim := new(pb.Claim)
+		err = proto.Unmarshal(serialized, claim)
+		if err != nil {
+			t.Error(test.Name, err)
+			continue
+		}
+		assert.Assert(t, proto.Equal(claim, migrated), test.Name)
+
+		expected := test.Claim
+		stream := claim.GetStream()
+		assert.Equal(t, claim.GetTitle(), expected.Title, test.Name)
+		assert.Equal(t, claim.GetDescription(), expected.Description, test.Name)
+		assert.Equal(t, stream.GetAuthor(), expected.Author, test.Name)
+		assert.Equal(t, stream.GetLicense(), expected.License, test.Name)
+		assert.Equal(t, stream.GetLicenseUrl(), expected.LicenseURL, test.Name)
+		assert.Equal(t, claim.GetLanguages()[0].String(), expected.Language, test.Name)
+		assert.Equal(t, claim.GetThumbnail().GetUrl(), expected.Thumbnail, test.Name)
+		assert.Equal(t, len(claim.GetTags()) == 1 && claim.GetTags()[0] == "mature", expected.NSFW, test.Name)
+		assert.Equal(t, stream.GetSource().GetMediaType(), expected.ContentType, test.Name)
+		assert.Equal(t, hex.EncodeToString(stream.GetSource().GetSdHash()), expected.LbrySDHash, test.Name)
+
+		if expected.FeeCurrency == "" {
+			assert.Assert(t, stream.GetFee() == nil, test.Name)
+			continue
+		}
+		assert.Equal(t, stream.GetFee().GetCurrency().String(), expected.FeeCurrency, test.Name)
+		assert.Equal(t, stream.GetFee().GetAmount(), feeAmount(stream.GetFee().GetCurrency(), expected.FeeAmount), test.Name)
+		var feeAddress [25]byte
+		copy(feeAddress[:], stream.GetFee().GetAddress())
+		encoded, err := address.EncodeAddress(feeAddress, "lbrycrd_main")
+		assert.NilError(t, err, test.Name)
+		assert.Equal(t, encoded, expected.FeeAddress, test.Name)
+	}
+}
+
+func TestFeeCurrencyWithoutPrecision(t *testing.T) {
+	eur := pb.Fee_Currency(len(pb.Fee_Currency_name))
+	FeeCurrencies["EUR"] = eur
+	defer delete(FeeCurrencies, "EUR")
+
+	err := setFee(&Fee{"EUR": &FeeInfo{Amount: 1, Address: "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}, newStreamClaim(), "lbrycrd_main")
+	assert.ErrorContains(t, err, "fee currency EUR has no precision")
+}
+
+func TestMigrateWarnings(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "warnings", "language": "klingon", "thumbnail": "thumb.png", "sources": {"lbry_sd_hash": "abc"}, "content_type": "video/mp4"}`)
+	claim, warnings, err := MigrateFromJSONWithWarnings(value, MigrationOptions{AllowMalformedSDHash: true})
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetThumbnail().GetUrl(), "thumb.png")
+	assert.DeepEqual(t, claim.GetStream().GetSource().GetSdHash(), []byte("abc"))
+	assert.DeepEqual(t, warnings, []string{
+		"unknown language 'klingon', migrating claim without a language",
+		"thumbnail url 'thumb.png' is not absolute, keeping it as is",
+		"sd hash has an odd number of hex characters (3), keeping it as is",
+	})
+}
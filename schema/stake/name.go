@@ -0,0 +1,80 @@
+package stake
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxClaimNameLength is the longest claim name, in bytes, lbrycrd accepts.
+const MaxClaimNameLength = 255
+
+const channelNamePrefix = "@"
+
+var (
+	ErrClaimNameEmpty            = errors.Base("claim name is empty")
+	ErrClaimNameTooLong          = errors.Base("claim name is longer than %d bytes", MaxClaimNameLength)
+	ErrClaimNameInvalidCharacter = errors.Base("claim name has an invalid character")
+	ErrChannelNameNoPrefix       = errors.Base("channel name does not start with %s", channelNamePrefix)
+)
+
+// invalidNameCharacters can't appear in a claim name because they have a meaning in lbry urls.
+const invalidNameCharacters = "=&#:$@%?;\"/\\<>{}|^~`[]"
+
+// IsValidClaimName checks a stream claim name against the rules the SDK applies to urls. Use errors.Is to tell
+// ErrClaimNameEmpty, ErrClaimNameTooLong and ErrClaimNameInvalidCharacter apart.
+func IsValidClaimName(name string) error {
+	if name == "" {
+		return errors.Err(ErrClaimNameEmpty)
+	}
+	if len(name) > MaxClaimNameLength {
+		return errors.Err(ErrClaimNameTooLong)
+	}
+	if !utf8.ValidString(name) {
+		return errors.Prefix(fmt.Sprintf("'%s' is not valid utf-8", name), ErrClaimNameInvalidCharacter)
+	}
+	for _, r := range name {
+		if isInvalidNameRune(r) {
+			return errors.Prefix(fmt.Sprintf("%q in '%s'", r, name), ErrClaimNameInvalidCharacter)
+		}
+	}
+	return nil
+}
+
+// IsValidChannelName checks a channel name, which is a claim name with an @ in front.
+func IsValidChannelName(name string) error {
+	if !strings.HasPrefix(name, channelNamePrefix) {
+		return errors.Err(ErrChannelNameNoPrefix)
+	}
+	if len(name) > MaxClaimNameLength {
+		return errors.Err(ErrClaimNameTooLong)
+	}
+	return IsValidClaimName(strings.TrimPrefix(name, channelNamePrefix))
+}
+
+// NormalizeClaimName returns the form lbrycrd compares claim names in: NFD normalized and lower cased. Names that
+// compete for the same url normalize to the same string. The result is checked with IsValidClaimName, or with
+// IsValidChannelName if it starts with an @.
+func NormalizeClaimName(name string) (string, error) {
+	normalized := strings.ToLower(norm.NFD.String(name))
+	if strings.HasPrefix(normalized, channelNamePrefix) {
+		return normalized, IsValidChannelName(normalized)
+	}
+	return normalized, IsValidClaimName(normalized)
+}
+
+func isInvalidNameRune(r rune) bool {
+	switch {
+	case r <= 0x20, r == 0x7f:
+		return true
+	case r >= 0xd800 && r <= 0xdfff, r == 0xfffe, r == 0xffff:
+		return true
+	case r == utf8.RuneError:
+		return true
+	}
+	return strings.ContainsRune(invalidNameCharacters, r)
+}
@@ -0,0 +1,70 @@
+package stake
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"gotest.tools/assert"
+)
+
+func TestIsValidClaimName(t *testing.T) {
+	for _, name := range []string{"a", "my-video", "My_Video.2019", "ビデオ", "видео", strings.Repeat("a", MaxClaimNameLength)} {
+		assert.NilError(t, IsValidClaimName(name), name)
+	}
+
+	tests := []struct {
+		Name  string
+		Error error
+	}{
+		{"", ErrClaimNameEmpty},
+		{strings.Repeat("a", MaxClaimNameLength+1), ErrClaimNameTooLong},
+		{strings.Repeat("び", MaxClaimNameLength/3+1), ErrClaimNameTooLong},
+		{"my video", ErrClaimNameInvalidCharacter},
+		{"@channel", ErrClaimNameInvalidCharacter},
+		{"a#b", ErrClaimNameInvalidCharacter},
+		{"a:b", ErrClaimNameInvalidCharacter},
+		{"a/b", ErrClaimNameInvalidCharacter},
+		{"a\u0000b", ErrClaimNameInvalidCharacter},
+		{"a\nb", ErrClaimNameInvalidCharacter},
+		{"a￿b", ErrClaimNameInvalidCharacter},
+		{"\xff", ErrClaimNameInvalidCharacter},
+	}
+	for _, test := range tests {
+		err := IsValidClaimName(test.Name)
+		assert.Assert(t, errors.Is(err, test.Error), "%q: expected %v, got %v", test.Name, test.Error, err)
+	}
+}
+
+func TestIsValidChannelName(t *testing.T) {
+	assert.NilError(t, IsValidChannelName("@channel"))
+	assert.NilError(t, IsValidChannelName("@"+strings.Repeat("a", MaxClaimNameLength-1)))
+
+	assert.Assert(t, errors.Is(IsValidChannelName("channel"), ErrChannelNameNoPrefix))
+	assert.Assert(t, errors.Is(IsValidChannelName("@"), ErrClaimNameEmpty))
+	assert.Assert(t, errors.Is(IsValidChannelName("@@channel"), ErrClaimNameInvalidCharacter))
+	assert.Assert(t, errors.Is(IsValidChannelName("@"+strings.Repeat("a", MaxClaimNameLength)), ErrClaimNameTooLong))
+}
+
+func TestNormalizeClaimName(t *testing.T) {
+	tests := map[string]string{
+		"Video":      "video",
+		"@MyChannel": "@mychannel",
+		// precomposed and decomposed forms normalize to the same name
+		"caf\u00e9":  "cafe\u0301",
+		"cafe\u0301": "cafe\u0301",
+		"\u00c9COLE": "e\u0301cole",
+		"Видео":      "видео",
+	}
+	for name, expected := range tests {
+		normalized, err := NormalizeClaimName(name)
+		assert.NilError(t, err, name)
+		assert.Equal(t, normalized, expected, name)
+	}
+
+	_, err := NormalizeClaimName("@")
+	assert.Assert(t, errors.Is(err, ErrClaimNameEmpty), err)
+	_, err = NormalizeClaimName("My Video")
+	assert.Assert(t, errors.Is(err, ErrClaimNameInvalidCharacter), err)
+}
@@ -0,0 +1,76 @@
+package stake
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+const txidLength = 32
+
+// Outpoint is a transaction output, with the txid in the byte order RPC calls and block explorers display it in.
+type Outpoint struct {
+	TxID string
+	Nout uint32
+}
+
+// ParseOutpoint parses the canonical "txid:nout" form of an outpoint.
+func ParseOutpoint(outpoint string) (Outpoint, error) {
+	parts := strings.Split(outpoint, ":")
+	if len(parts) != 2 {
+		return Outpoint{}, errors.Err("outpoint '%s' is not in the txid:nout form", outpoint)
+	}
+	txid := strings.ToLower(parts[0])
+	if _, err := decodeTxID(txid); err != nil {
+		return Outpoint{}, err
+	}
+	nout, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return Outpoint{}, errors.Err("outpoint '%s' has an invalid nout", outpoint)
+	}
+	return Outpoint{TxID: txid, Nout: uint32(nout)}, nil
+}
+
+func (o Outpoint) String() string {
+	return o.TxID + ":" + strconv.FormatUint(uint64(o.Nout), 10)
+}
+
+// ClaimID returns the id of the claim created by this outpoint.
+func (o Outpoint) ClaimID() (string, error) {
+	return ClaimIDFromOutpoint(o.TxID, o.Nout)
+}
+
+// ClaimIDFromOutpoint computes the id of the claim created by an output. The claim id is the RIPEMD160 of the SHA256
+// of the txid in its internal byte order followed by the big endian nout. Both the txid that is passed in and the
+// claim id that is returned are in display order, which is the reverse of the internal one.
+func ClaimIDFromOutpoint(txid string, nout uint32) (string, error) {
+	txidBytes, err := decodeTxID(txid)
+	if err != nil {
+		return "", err
+	}
+
+	noutBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(noutBytes, nout)
+	hash := sha256.Sum256(append(reverseBytes(txidBytes), noutBytes...))
+
+	r := ripemd160.New()
+	r.Write(hash[:])
+	return hex.EncodeToString(reverseBytes(r.Sum(nil))), nil
+}
+
+func decodeTxID(txid string) ([]byte, error) {
+	txidBytes, err := hex.DecodeString(txid)
+	if err != nil {
+		return nil, errors.Prefix("txid '"+txid+"' is not hex", err)
+	}
+	if len(txidBytes) != txidLength {
+		return nil, errors.Err("txid '%s' is %d bytes, expected %d", txid, len(txidBytes), txidLength)
+	}
+	return txidBytes, nil
+}
@@ -0,0 +1,71 @@
+package stake
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// outpointTests are mainnet claims, the claim ids can be checked on any block explorer.
+var outpointTests = []struct {
+	Outpoint string
+	ClaimID  string
+}{
+	{"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:1", "589bc4845caca70977332025990b2a1807732b44"},
+	{"2850f854108d9fd1d9067cc51ef38664f320cda363741a5774f8c6f0c154a702:1", "60d7ddcc211c381bad63b73415c2065b219258f2"},
+	{"6ec15e7a80205fe2fb08eb57a5e4866544db56d81ebfc21d16a19c01a3394779:0", "015a97bef520a8b121baec02f9fd36a9f7e8a17e"},
+	{"1cd52537daa096d5fa2b0d20cbcf907fb1a1dc22436f48902473d8af1f7ebe07:0", "ed15aff6c77d0ae46b542aced757b04f7ec4a507"},
+	{"bde00b2ba8ca425fff0d814d12a67d0ce99950365eb21081011aaf4a8c5f3e8b:0", "bef5806ebee8816bcc8d10684eeb5d0d7c906c87"},
+	{"de0a48529d193ae33402f9620a25d91d7f33e608022d5e32451acd1d30fe7933:0", "8b120c045300923062d82911868febffccb502bf"},
+	{"caf6a81ec886ed2a930a16814f0fdd488a753b22a77f5fb67a11fd3b985edb15:0", "84cae80fbe8e49eb45a69ea3af884016c58e8ccb"},
+	{"3cef7db4221d17d544d0595ac34a27db974e1202bac63db126186c218b48f1d1:0", "cafe80622cebed351cd0b441f44f42802a0e7dce"},
+}
+
+func TestClaimIDFromOutpoint(t *testing.T) {
+	for _, test := range outpointTests {
+		outpoint, err := ParseOutpoint(test.Outpoint)
+		assert.NilError(t, err, test.Outpoint)
+		assert.Equal(t, outpoint.String(), test.Outpoint)
+
+		claimID, err := outpoint.ClaimID()
+		assert.NilError(t, err, test.Outpoint)
+		assert.Equal(t, claimID, test.ClaimID)
+
+		claimID, err = ClaimIDFromOutpoint(strings.ToUpper(outpoint.TxID), outpoint.Nout)
+		assert.NilError(t, err, test.Outpoint)
+		assert.Equal(t, claimID, test.ClaimID)
+	}
+
+	// the txid in internal byte order gives a different claim id
+	txid := "6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df"
+	claimID, err := ClaimIDFromOutpoint(txid, 1)
+	assert.NilError(t, err)
+	reversed, err := ClaimIDFromOutpoint(hexReverse(txid), 1)
+	assert.NilError(t, err)
+	assert.Assert(t, claimID != reversed)
+}
+
+func TestParseOutpointErrors(t *testing.T) {
+	for _, outpoint := range []string{
+		"",
+		"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df",
+		"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:",
+		"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:-1",
+		"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:4294967296",
+		"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:1:2",
+		"6a9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5:1",
+		"zz9dbe3084b86cec8aa519970d2245dfa15193294cab65819a0d96d455c2a5df:1",
+	} {
+		_, err := ParseOutpoint(outpoint)
+		assert.Assert(t, err != nil, outpoint)
+	}
+}
+
+func hexReverse(value string) string {
+	var reversed strings.Builder
+	for i := len(value) - 2; i >= 0; i -= 2 {
+		reversed.WriteString(value[i : i+2])
+	}
+	return reversed.String()
+}
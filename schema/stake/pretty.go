@@ -1,8 +1,15 @@
 package stake
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/schema/address/base58"
+	pb "github.com/lbryio/types/v2/go"
 
 	"github.com/golang/protobuf/jsonpb"
 )
@@ -30,3 +37,157 @@ func (c *StakeHelper) RenderJSON() (string, error) {
 }
 
 //TODO: encode byte arrays with b58 for addresses and b16 for source hashes instead of the default of b64
+
+// claimDump is the readable form of a claim shared by Dump and DumpJSON. Fields that aren't set are left out.
+type claimDump struct {
+	Type        string   `json:"type"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	Languages   []string `json:"languages,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Thumbnail   string   `json:"thumbnail,omitempty"`
+	License     string   `json:"license,omitempty"`
+	LicenseURL  string   `json:"license_url,omitempty"`
+	MediaType   string   `json:"media_type,omitempty"`
+	SDHash      string   `json:"sd_hash,omitempty"`
+	Fee         *feeDump `json:"fee,omitempty"`
+	PublicKey   string   `json:"public_key,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	WebsiteURL  string   `json:"website_url,omitempty"`
+	Reposted    string   `json:"reposted_claim_id,omitempty"`
+	Claims      []string `json:"claim_ids,omitempty"`
+	Emoji       string   `json:"emoji,omitempty"`
+	Signed      bool     `json:"signed"`
+	ChannelID   string   `json:"channel_id,omitempty"`
+}
+
+type feeDump struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	Address  string `json:"address,omitempty"`
+}
+
+func (f feeDump) String() string {
+	if f.Address == "" {
+		return f.Amount + " " + f.Currency
+	}
+	return f.Amount + " " + f.Currency + " to " + f.Address
+}
+
+func (c *StakeHelper) dump() claimDump {
+	d := claimDump{Type: "empty"}
+	if c.Signature != nil {
+		d.Signed = true
+		d.ChannelID = hex.EncodeToString(reverseBytes(c.ClaimID))
+	}
+
+	if c.IsSupport() {
+		d.Type = "support"
+		d.Emoji = c.Support.GetEmoji()
+		return d
+	}
+	if c.Claim == nil {
+		return d
+	}
+
+	claim := c.Claim
+	d.Type = claimTypeName(claim)
+	d.Title = claim.GetTitle()
+	d.Description = claim.GetDescription()
+	d.Tags = claim.GetTags()
+	d.Thumbnail = claim.GetThumbnail().GetUrl()
+	for _, language := range claim.GetLanguages() {
+		d.Languages = append(d.Languages, pb.Language_Language_name[int32(language.GetLanguage())])
+	}
+
+	switch {
+	case claim.GetStream() != nil:
+		stream := claim.GetStream()
+		d.Author = stream.GetAuthor()
+		d.License = stream.GetLicense()
+		d.LicenseURL = stream.GetLicenseUrl()
+		d.MediaType = stream.GetSource().GetMediaType()
+		if sdHash := stream.GetSource().GetSdHash(); len(sdHash) > 0 {
+			d.SDHash = hex.EncodeToString(sdHash)
+		}
+		if fee := stream.GetFee(); fee != nil {
+			d.Fee = &feeDump{
+				Amount:   strconv.FormatUint(fee.GetAmount(), 10),
+				Currency: fee.GetCurrency().String(),
+			}
+			if amount, ok := feeValue(fee.GetCurrency(), fee.GetAmount()); ok {
+				d.Fee.Amount = strconv.FormatFloat(amount, 'f', -1, 64)
+			}
+			if len(fee.GetAddress()) == addressLength {
+				d.Fee.Address = base58.EncodeBase58(fee.GetAddress())
+			} else if len(fee.GetAddress()) > 0 {
+				d.Fee.Address = hex.EncodeToString(fee.GetAddress())
+			}
+		}
+	case claim.GetChannel() != nil:
+		channel := claim.GetChannel()
+		d.PublicKey = hex.EncodeToString(channel.GetPublicKey())
+		d.Email = channel.GetEmail()
+		d.WebsiteURL = channel.GetWebsiteUrl()
+	case claim.GetRepost() != nil:
+		d.Reposted = hex.EncodeToString(reverseBytes(claim.GetRepost().GetClaimHash()))
+	case claim.GetCollection() != nil:
+		for _, reference := range claim.GetCollection().GetClaimReferences() {
+			d.Claims = append(d.Claims, hex.EncodeToString(reverseBytes(reference.GetClaimHash())))
+		}
+	}
+	return d
+}
+
+// Dump renders the claim as an indented, labeled text block for debugging and support tooling. Enums are shown by
+// name, hashes as hex and fee addresses in base58. Fields that aren't set are left out.
+func (c *StakeHelper) Dump() string {
+	d := c.dump()
+	b := new(strings.Builder)
+	b.WriteString(d.Type + "\n")
+	field := func(label string, value string) {
+		if value != "" {
+			fmt.Fprintf(b, "  %-13s %s\n", label+":", value)
+		}
+	}
+
+	field("title", d.Title)
+	field("description", d.Description)
+	field("author", d.Author)
+	field("languages", strings.Join(d.Languages, ", "))
+	field("tags", strings.Join(d.Tags, ", "))
+	field("thumbnail", d.Thumbnail)
+	field("license", d.License)
+	field("license url", d.LicenseURL)
+	field("media type", d.MediaType)
+	field("sd hash", d.SDHash)
+	if d.Fee != nil {
+		field("fee", d.Fee.String())
+	}
+	field("public key", d.PublicKey)
+	field("email", d.Email)
+	field("website", d.WebsiteURL)
+	field("reposted", d.Reposted)
+	field("claims", strings.Join(d.Claims, ", "))
+	field("emoji", d.Emoji)
+	if d.Signed {
+		field("signed by", d.ChannelID)
+	} else {
+		field("signature", "none")
+	}
+	return b.String()
+}
+
+func (c *StakeHelper) String() string {
+	return c.Dump()
+}
+
+// DumpJSON renders the same fields as Dump as json, for scripts that consume it.
+func (c *StakeHelper) DumpJSON() (string, error) {
+	value, err := json.MarshalIndent(c.dump(), "", "  ")
+	if err != nil {
+		return "", errors.Err(err)
+	}
+	return string(value), nil
+}
@@ -0,0 +1,82 @@
+package stake
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestDumpSignedStream(t *testing.T) {
+	claim, err := NewClaimBuilder().
+		Title("title").
+		Language(pb.Language_en).
+		NSFW(true).
+		Fee(pb.Fee_LBC, 1.5, "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6").
+		SDHash(testSDHash).
+		ContentType("video/mp4").
+		Build()
+	assert.NilError(t, err)
+
+	channelID := "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb"
+	claimID, err := hex.DecodeString(channelID)
+	assert.NilError(t, err)
+	helper := &StakeHelper{Claim: claim, Version: WithSig, ClaimID: reverseBytes(claimID), Signature: make([]byte, 64)}
+
+	dump := helper.Dump()
+	assert.Assert(t, strings.HasPrefix(dump, "stream\n"), dump)
+	for _, line := range []string{
+		"  title:        title\n",
+		"  languages:    en\n",
+		"  tags:         mature\n",
+		"  media type:   video/mp4\n",
+		"  sd hash:      " + testSDHash + "\n",
+		"  fee:          1.5 LBC to bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6\n",
+		"  signed by:    " + channelID + "\n",
+	} {
+		assert.Assert(t, strings.Contains(dump, line), "missing %q in\n%s", line, dump)
+	}
+	assert.Assert(t, !strings.Contains(dump, "<nil>"), dump)
+	assert.Assert(t, !strings.Contains(dump, "author"), dump)
+	assert.Equal(t, helper.String(), dump)
+
+	value, err := helper.DumpJSON()
+	assert.NilError(t, err)
+	var rendered map[string]interface{}
+	assert.NilError(t, json.Unmarshal([]byte(value), &rendered))
+	assert.Equal(t, rendered["type"], "stream")
+	assert.Equal(t, rendered["signed"], true)
+	assert.Equal(t, rendered["channel_id"], channelID)
+	assert.Equal(t, rendered["sd_hash"], testSDHash)
+	assert.DeepEqual(t, rendered["fee"], map[string]interface{}{"amount": "1.5", "currency": "LBC", "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"})
+	_, hasAuthor := rendered["author"]
+	assert.Assert(t, !hasAuthor)
+}
+
+func TestDumpChannel(t *testing.T) {
+	privateKey, _, err := NewChannelKey()
+	assert.NilError(t, err)
+	channel, err := NewChannelClaim(privateKey.PubKey())
+	assert.NilError(t, err)
+	channel.Claim.Title = "channel"
+
+	dump := channel.Dump()
+	assert.Assert(t, strings.HasPrefix(dump, "channel\n"), dump)
+	assert.Assert(t, strings.Contains(dump, "  public key:   "+hex.EncodeToString(channel.Claim.GetChannel().GetPublicKey())+"\n"), dump)
+	assert.Assert(t, strings.Contains(dump, "  signature:    none\n"), dump)
+	assert.Assert(t, !strings.Contains(dump, "\n  fee:"), dump)
+
+	value, err := channel.DumpJSON()
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(value, `"type": "channel"`), value)
+	assert.Assert(t, strings.Contains(value, `"signed": false`), value)
+}
+
+func TestDumpSupport(t *testing.T) {
+	support := &StakeHelper{Support: &pb.Support{Emoji: "🚀"}}
+	assert.Equal(t, support.Dump(), "support\n  emoji:        🚀\n  signature:    none\n")
+}
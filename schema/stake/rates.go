@@ -0,0 +1,65 @@
+package stake
+
+import (
+	"math"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// dewiesPerLBC is how many of the smallest units there are in one LBC.
+const dewiesPerLBC = 100000000
+
+// RateProvider supplies the exchange rates FeeInLBC converts fees with. Implementations decide where the rates come
+// from and how fresh they are, so this package never goes to the network for them.
+type RateProvider interface {
+	// LBCPer returns how many LBC one unit of currency is worth, e.g. 50 if an LBC costs 2 US cents.
+	LBCPer(currency pb.Fee_Currency) (float64, error)
+}
+
+// StaticRates is a RateProvider with fixed rates, for tests and for callers that look rates up once.
+type StaticRates map[pb.Fee_Currency]float64
+
+func (r StaticRates) LBCPer(currency pb.Fee_Currency) (float64, error) {
+	rate, ok := r[currency]
+	if !ok {
+		return 0, errors.Err("no exchange rate for %s", currency)
+	}
+	return rate, nil
+}
+
+// FeeInLBC returns what a fee costs in LBC. A nil fee or a fee of 0 is free and doesn't need a rate, and LBC fees are
+// returned as they are. Fees in other currencies are read in the unit FeePrecisions gives them, converted with the
+// rate from rates and rounded to the nearest dewy, halves away from zero, so the same fee and rate always come to the
+// same number of dewies.
+func FeeInLBC(fee *pb.Fee, rates RateProvider) (float64, error) {
+	if fee.GetAmount() == 0 {
+		return 0, nil
+	}
+
+	currency := fee.GetCurrency()
+	amount, ok := feeValue(currency, fee.GetAmount())
+	if _, known := currencyCode(currency); !known || !ok {
+		return 0, errors.Err(ErrUnknownCurrency{Currency: currency.String()})
+	}
+	if currency == pb.Fee_LBC {
+		return amount, nil
+	}
+
+	if rates == nil {
+		return 0, errors.Err("a rate provider is needed to convert a %s fee", currency)
+	}
+	rate, err := rates.LBCPer(currency)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(rate) || math.IsInf(rate, 0) || rate <= 0 {
+		return 0, errors.Err("exchange rate %v for %s is not a positive number", rate, currency)
+	}
+
+	dewies := math.Round(amount * rate * dewiesPerLBC)
+	if dewies > math.MaxInt64 {
+		return 0, errors.Err("%s fee of %d is too large to convert", currency, fee.GetAmount())
+	}
+	return dewies / dewiesPerLBC, nil
+}
@@ -0,0 +1,55 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestFeeInLBC(t *testing.T) {
+	rates := StaticRates{pb.Fee_USD: 50, pb.Fee_BTC: 3000000}
+	tests := []struct {
+		Fee *pb.Fee
+		LBC float64
+	}{
+		{nil, 0},
+		{&pb.Fee{Currency: pb.Fee_USD}, 0},
+		{&pb.Fee{Currency: pb.Fee_LBC, Amount: 250000000}, 2.5},
+		// USD fees are in cents, as lbry-sdk publishes them
+		{&pb.Fee{Currency: pb.Fee_USD, Amount: 150}, 75},
+		{&pb.Fee{Currency: pb.Fee_USD, Amount: 1}, 0.5},
+		{&pb.Fee{Currency: pb.Fee_BTC, Amount: 1000}, 30},
+	}
+	for _, test := range tests {
+		lbc, err := FeeInLBC(test.Fee, rates)
+		assert.NilError(t, err, test.Fee)
+		assert.Equal(t, lbc, test.LBC, test.Fee)
+	}
+
+	lbc, err := FeeInLBC(&pb.Fee{Currency: pb.Fee_LBC, Amount: 100000000}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, lbc, 1.0)
+
+	// a cent at half a millionth of an LBC to the dollar is half a dewy, which rounds up
+	lbc, err = FeeInLBC(&pb.Fee{Currency: pb.Fee_USD, Amount: 1}, StaticRates{pb.Fee_USD: 0.0000005})
+	assert.NilError(t, err)
+	assert.Equal(t, lbc, 0.00000001)
+}
+
+func TestFeeInLBCErrors(t *testing.T) {
+	usd := &pb.Fee{Currency: pb.Fee_USD, Amount: 100}
+
+	_, err := FeeInLBC(usd, nil)
+	assert.ErrorContains(t, err, "rate provider is needed")
+	_, err = FeeInLBC(usd, StaticRates{pb.Fee_BTC: 1})
+	assert.ErrorContains(t, err, "no exchange rate for USD")
+	_, err = FeeInLBC(usd, StaticRates{pb.Fee_USD: -1})
+	assert.ErrorContains(t, err, "is not a positive number")
+
+	_, err = FeeInLBC(&pb.Fee{Currency: pb.Fee_UNKNOWN_CURRENCY, Amount: 1}, StaticRates{})
+	_, ok := errors.Unwrap(err).(ErrUnknownCurrency)
+	assert.Assert(t, ok, "expected ErrUnknownCurrency, got %v", err)
+}
@@ -0,0 +1,109 @@
+package stake
+
+import (
+	"strings"
+
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// ContentRating is an MPAA or TV Parental Guidelines style rating of who a claim's content is suitable for. It is
+// finer grained than the mature tag, which it doesn't replace.
+type ContentRating string
+
+const (
+	RatingG    ContentRating = "G"
+	RatingPG   ContentRating = "PG"
+	RatingPG13 ContentRating = "PG13"
+	RatingR    ContentRating = "R"
+	RatingNC17 ContentRating = "NC17"
+	RatingTVMA ContentRating = "TV_MA"
+	RatingTV14 ContentRating = "TV_14"
+	RatingTVPG ContentRating = "TV_PG"
+	RatingTVY  ContentRating = "TV_Y"
+	RatingTVY7 ContentRating = "TV_Y7"
+)
+
+// ratingTagPrefix is put in front of a rating to store it as a claim tag, e.g. "rating:PG13".
+const ratingTagPrefix = "rating:"
+
+// contentRatings maps ratings, with case and separators stripped by ratingKey, to a ContentRating. Besides the
+// ratings themselves it knows the way YouTube writes them and its audience settings.
+var contentRatings = map[string]ContentRating{
+	"G":    RatingG,
+	"PG":   RatingPG,
+	"PG13": RatingPG13,
+	"R":    RatingR,
+	"NC17": RatingNC17,
+	"TVMA": RatingTVMA,
+	"TV14": RatingTV14,
+	"TVPG": RatingTVPG,
+	"TVY":  RatingTVY,
+	"TVY7": RatingTVY7,
+	"TVG":  RatingG,
+
+	"MPAAG":    RatingG,
+	"MPAAPG":   RatingPG,
+	"MPAAPG13": RatingPG13,
+	"MPAAR":    RatingR,
+	"MPAANC17": RatingNC17,
+	"TVPGMA":   RatingTVMA,
+	"TVPG14":   RatingTV14,
+	"TVPGPG":   RatingTVPG,
+	"TVPGY":    RatingTVY,
+	"TVPGY7":   RatingTVY7,
+	"TVPGG":    RatingG,
+
+	"YTAGERESTRICTED": RatingTVMA,
+	"MADEFORKIDS":     RatingTVY,
+	"MADEFORKIDSTRUE": RatingTVY,
+}
+
+// NormalizeContentRating maps a free form rating to a ContentRating. Matching ignores case, spaces, dashes,
+// underscores and dots, so "PG-13", "pg13" and YouTube's "mpaaPg13" are all PG13, and YouTube's "madeForKids=true"
+// is TV_Y. It returns false if the rating isn't recognized.
+func NormalizeContentRating(raw string) (ContentRating, bool) {
+	rating, ok := contentRatings[ratingKey(raw)]
+	return rating, ok
+}
+
+func ratingKey(raw string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '.', '=':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(raw)))
+}
+
+// GetContentRating returns the rating the claim is tagged with, if it has one.
+func GetContentRating(claim *pb.Claim) (ContentRating, bool) {
+	for _, tag := range claim.GetTags() {
+		if raw, ok := ratingFromTag(tag); ok {
+			if rating, ok := NormalizeContentRating(raw); ok {
+				return rating, true
+			}
+		}
+	}
+	return "", false
+}
+
+// setContentRating tags the claim with rating, replacing any rating it was tagged with before.
+func setContentRating(claim *pb.Claim, rating ContentRating) {
+	tags := claim.Tags[:0]
+	for _, tag := range claim.GetTags() {
+		if _, ok := ratingFromTag(tag); !ok {
+			tags = append(tags, tag)
+		}
+	}
+	claim.Tags = append(tags, ratingTagPrefix+string(rating))
+}
+
+// ratingFromTag returns what follows the rating: prefix of a tag, which is matched case insensitively.
+func ratingFromTag(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	if len(tag) < len(ratingTagPrefix) || !strings.EqualFold(tag[:len(ratingTagPrefix)], ratingTagPrefix) {
+		return "", false
+	}
+	return tag[len(ratingTagPrefix):], true
+}
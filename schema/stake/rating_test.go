@@ -0,0 +1,65 @@
+package stake
+
+import (
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestNormalizeContentRating(t *testing.T) {
+	tests := []struct {
+		Raw    string
+		Rating ContentRating
+	}{
+		{"G", RatingG},
+		{"PG-13", RatingPG13},
+		{" pg13 ", RatingPG13},
+		{"NC-17", RatingNC17},
+		{"TV-MA", RatingTVMA},
+		{"tv_y7", RatingTVY7},
+		{"mpaaPg13", RatingPG13},
+		{"mpaaNc17", RatingNC17},
+		{"tvpg14", RatingTV14},
+		{"tvpgY", RatingTVY},
+		{"ytAgeRestricted", RatingTVMA},
+		{"madeForKids=true", RatingTVY},
+	}
+	for _, test := range tests {
+		rating, ok := NormalizeContentRating(test.Raw)
+		assert.Assert(t, ok, test.Raw)
+		assert.Equal(t, rating, test.Rating)
+	}
+
+	for _, raw := range []string{"", "PG-18", "madeForKids=false", "mature"} {
+		_, ok := NormalizeContentRating(raw)
+		assert.Assert(t, !ok, raw)
+	}
+}
+
+func TestContentRatingTag(t *testing.T) {
+	claim := &pb.Claim{Tags: []string{"music"}}
+	_, ok := GetContentRating(claim)
+	assert.Assert(t, !ok)
+
+	setContentRating(claim, RatingPG)
+	setContentRating(claim, RatingR)
+	assert.DeepEqual(t, claim.GetTags(), []string{"music", "rating:R"})
+	rating, ok := GetContentRating(claim)
+	assert.Assert(t, ok)
+	assert.Equal(t, rating, RatingR)
+
+	rating, ok = GetContentRating(&pb.Claim{Tags: []string{"Rating:tv-14"}})
+	assert.Assert(t, ok)
+	assert.Equal(t, rating, RatingTV14)
+}
+
+func TestBuilderRating(t *testing.T) {
+	claim, err := validBuilder().NSFW(true).Rating("PG-13").Build()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, claim.GetTags(), []string{"mature", "rating:PG13"})
+
+	_, err = validBuilder().Rating("XXX").Build()
+	assert.ErrorContains(t, err, "unknown content rating")
+}
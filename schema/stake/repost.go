@@ -0,0 +1,51 @@
+package stake
+
+import (
+	"encoding/hex"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+const claimIDLength = 20
+
+// NewRepost creates an unsigned repost of the claim with the given id. The title, description, tags and thumbnail of
+// the returned claim can be set to override the ones of the reposted claim.
+func NewRepost(claimID string) (*StakeHelper, error) {
+	claimHash, err := decodeClaimID(claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	claim := &pb.Claim{Type: &pb.Claim_Repost{Repost: &pb.ClaimReference{ClaimHash: claimHash}}}
+	return &StakeHelper{Claim: claim, Version: NoSig}, nil
+}
+
+// IsRepost reports whether the claim is a repost of another claim.
+func (c *StakeHelper) IsRepost() bool {
+	return c.IsClaim() && c.Claim.GetRepost() != nil
+}
+
+// RepostedClaimID returns the id of the claim this claim reposts.
+func (c *StakeHelper) RepostedClaimID() (string, error) {
+	if !c.IsRepost() {
+		return "", errors.Err("claim is not a repost")
+	}
+	claimHash := c.Claim.GetRepost().GetClaimHash()
+	if len(claimHash) != claimIDLength {
+		return "", errors.Err("reposted claim id is %d bytes, expected %d", len(claimHash), claimIDLength)
+	}
+	return hex.EncodeToString(reverseBytes(claimHash)), nil
+}
+
+// decodeClaimID turns a hex claim id into the claim hash stored in claims, which is in the reverse byte order.
+func decodeClaimID(claimID string) ([]byte, error) {
+	claimIDBytes, err := hex.DecodeString(claimID)
+	if err != nil {
+		return nil, errors.Prefix("claim id '"+claimID+"' is not hex", err)
+	}
+	if len(claimIDBytes) != claimIDLength {
+		return nil, errors.Err("claim id '%s' is %d bytes, expected %d", claimID, len(claimIDBytes), claimIDLength)
+	}
+	return reverseBytes(claimIDBytes), nil
+}
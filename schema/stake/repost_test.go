@@ -0,0 +1,49 @@
+package stake
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNewRepost(t *testing.T) {
+	claimID := "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb"
+	repost, err := NewRepost(claimID)
+	assert.NilError(t, err)
+	repost.Claim.Title = "mirrored"
+	repost.Claim.Tags = []string{"mirror"}
+	assert.NilError(t, Validate(repost.Claim))
+
+	value, err := repost.CompileValue()
+	assert.NilError(t, err)
+	decoded, err := DecodeClaimBytes(value, "lbrycrd_main")
+	assert.NilError(t, err)
+
+	assert.Assert(t, decoded.IsRepost())
+	assert.Equal(t, claimTypeName(decoded.Claim), "repost")
+	reposted, err := decoded.RepostedClaimID()
+	assert.NilError(t, err)
+	assert.Equal(t, reposted, claimID)
+	assert.Equal(t, decoded.Claim.GetTitle(), "mirrored")
+	assert.DeepEqual(t, decoded.Claim.GetTags(), []string{"mirror"})
+	assert.Equal(t, decoded.Claim.GetRepost().GetClaimHash()[0], byte(0xdb))
+}
+
+func TestNewRepostErrors(t *testing.T) {
+	for _, claimID := range []string{"", "cf3f7c898af87cc69b06a6ac7899efb9a4878f", "cf3f7c898af87cc69b06a6ac7899efb9a4878fdb00", "zz3f7c898af87cc69b06a6ac7899efb9a4878fdb"} {
+		_, err := NewRepost(claimID)
+		assert.Assert(t, err != nil, claimID)
+	}
+
+	repost, err := NewRepost("cf3f7c898af87cc69b06a6ac7899efb9a4878fdb")
+	assert.NilError(t, err)
+	repost.Claim.GetRepost().ClaimHash = repost.Claim.GetRepost().ClaimHash[:19]
+	assert.ErrorContains(t, Validate(repost.Claim), "reposted claim id is 19 bytes")
+	_, err = repost.RepostedClaimID()
+	assert.Assert(t, err != nil)
+
+	stream := &StakeHelper{Claim: validStreamClaim(t)}
+	assert.Assert(t, !stream.IsRepost())
+	_, err = stream.RepostedClaimID()
+	assert.ErrorContains(t, err, "not a repost")
+}
@@ -0,0 +1,101 @@
+package stake
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	"github.com/lbryio/lbry.go/v2/schema/address/base58"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+const v3Version = "0.0.3"
+
+// ToV3JSON renders a stream claim in the V3 json format for tools that don't read protobufs. It is the reverse of
+// migrating a V3 claim, so only the fields V3 has are kept. Other claim types have no V3 representation.
+func ToV3JSON(claim *pb.Claim) ([]byte, error) {
+	v3Claim, err := toV3Claim(claim)
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(v3Claim)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return value, nil
+}
+
+func toV3Claim(claim *pb.Claim) (*V3Claim, error) {
+	if claim == nil {
+		return nil, errors.Err("claim is nil")
+	}
+	stream := claim.GetStream()
+	if stream == nil {
+		return nil, errors.Err("%s claims have no V3 representation", claimTypeName(claim))
+	}
+
+	v3Claim := &V3Claim{
+		Version:     v3Version,
+		Title:       claim.GetTitle(),
+		Description: claim.GetDescription(),
+		Author:      stream.GetAuthor(),
+		License:     stream.GetLicense(),
+		ContentType: stream.GetSource().GetMediaType(),
+		Sources:     Sources{LbrySDHash: hex.EncodeToString(stream.GetSource().GetSdHash()), IPFSCID: ipfsCID(stream.GetSource())},
+	}
+
+	if languages := claim.GetLanguages(); len(languages) > 0 && languages[0].GetLanguage() != pb.Language_UNKNOWN_LANGUAGE {
+		v3Claim.Language = languages[0].GetLanguage().String()
+	}
+	if thumbnail := claim.GetThumbnail().GetUrl(); thumbnail != "" {
+		v3Claim.Thumbnail = &thumbnail
+	}
+	if licenseURL := stream.GetLicenseUrl(); licenseURL != "" {
+		v3Claim.LicenseURL = &licenseURL
+	}
+	v3Claim.NSFW = IsMature(claim)
+
+	if stream.GetFee() != nil {
+		fee, err := toV3Fee(stream.GetFee())
+		if err != nil {
+			return nil, err
+		}
+		v3Claim.Fee = fee
+	}
+
+	return v3Claim, nil
+}
+
+func toV3Fee(fee *pb.Fee) (*Fee, error) {
+	amount, ok := feeValue(fee.GetCurrency(), fee.GetAmount())
+	if !ok {
+		return nil, errors.Err("fee currency %s has no V3 representation", fee.GetCurrency())
+	}
+	info := &FeeInfo{Amount: float32(amount)}
+	if len(fee.GetAddress()) > 0 {
+		if len(fee.GetAddress()) != addressLength || !base58.VerifyBase58Checksum(fee.GetAddress()) {
+			return nil, errors.Err("fee address %s is not a valid address", hex.EncodeToString(fee.GetAddress()))
+		}
+		info.Address = base58.EncodeBase58(fee.GetAddress())
+	}
+
+	code, ok := currencyCode(fee.GetCurrency())
+	if !ok {
+		return nil, errors.Err("fee currency %s has no V3 representation", fee.GetCurrency())
+	}
+	return &Fee{code: info}, nil
+}
+
+func claimTypeName(claim *pb.Claim) string {
+	switch claim.GetType().(type) {
+	case *pb.Claim_Stream:
+		return "stream"
+	case *pb.Claim_Channel:
+		return "channel"
+	case *pb.Claim_Collection:
+		return "collection"
+	case *pb.Claim_Repost:
+		return "repost"
+	}
+	return "untyped"
+}
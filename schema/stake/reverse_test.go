@@ -0,0 +1,65 @@
+package stake
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+var v3JSONTests = []string{
+	`{"ver": "0.0.3", "title": "all fields", "description": "description", "author": "author", "language": "fr", "license": "CC-BY", "license_url": "https://creativecommons.org/licenses/by/4.0/", "content_type": "video/mp4", "thumbnail": "https://example.com/thumbnail.jpg", "nsfw": true, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"LBC": {"amount": 2.5, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}, "sig": null}`,
+	`{"ver": "0.0.3", "title": "btc", "description": "", "author": "", "language": "ja", "license": "", "content_type": "image/png", "nsfw": false, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"BTC": {"amount": 0.001, "address": "r6stj4JjLFtCmynDqDVjmkDXS9zfJa56qc"}}}`,
+	`{"ver": "0.0.3", "title": "usd", "description": "", "author": "", "language": "en", "license": "", "content_type": "audio/mpeg", "nsfw": false, "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"USD": {"amount": 0.5, "address": "bDNaJ3H54ajnWwd1vhAwq79R9VTm2jnuSN"}}}`,
+	`{"ver": "0.0.3", "title": "free", "description": "", "author": "", "language": "", "license": "", "content_type": "application/pdf", "nsfw": false, "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+}
+
+func TestToV3JSON(t *testing.T) {
+	for _, test := range v3JSONTests {
+		var original V3Claim
+		assert.NilError(t, original.Unmarshal([]byte(test)))
+		claim, _, err := migrateV3Claim(original, MigrationOptions{})
+		assert.NilError(t, err, test)
+
+		value, err := ToV3JSON(claim)
+		assert.NilError(t, err, test)
+		var rendered V3Claim
+		assert.NilError(t, rendered.Unmarshal(value), string(value))
+		assert.DeepEqual(t, rendered, original)
+	}
+}
+
+func TestToV3JSONFromOlderVersions(t *testing.T) {
+	for _, test := range roundTripTests {
+		claim, err := migrateFixture([]byte(test.JSON))
+		assert.NilError(t, err, test.Name)
+		value, err := ToV3JSON(claim)
+		assert.NilError(t, err, test.Name)
+
+		var rendered V3Claim
+		assert.NilError(t, json.Unmarshal(value, &rendered), test.Name)
+		assert.Equal(t, rendered.Version, "0.0.3")
+		assert.Equal(t, rendered.Title, test.Claim.Title)
+		assert.Equal(t, rendered.NSFW, test.Claim.NSFW)
+		assert.Equal(t, rendered.Sources.LbrySDHash, test.Claim.LbrySDHash)
+	}
+}
+
+func TestToV3JSONErrors(t *testing.T) {
+	_, err := ToV3JSON(newChannelClaim())
+	assert.ErrorContains(t, err, "channel claims have no V3 representation")
+
+	_, err = ToV3JSON(nil)
+	assert.ErrorContains(t, err, "claim is nil")
+
+	claim := validStreamClaim(t)
+	claim.GetStream().Fee = &pb.Fee{Amount: 1, Currency: pb.Fee_UNKNOWN_CURRENCY}
+	_, err = ToV3JSON(claim)
+	assert.ErrorContains(t, err, "has no V3 representation")
+
+	claim.GetStream().Fee = &pb.Fee{Amount: 1, Currency: pb.Fee_LBC, Address: []byte{1, 2, 3}}
+	_, err = ToV3JSON(claim)
+	assert.ErrorContains(t, err, "is not a valid address")
+}
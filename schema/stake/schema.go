@@ -2,8 +2,13 @@ package stake
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 
 	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
 )
 
 // V1Claim is the first version of claim metadata used by lbry.
@@ -20,6 +25,7 @@ type V1Claim struct {
 	Fee         *Fee    `json:"fee,omitempty"`
 	Contact     *int    `json:"contact,omitempty"`
 	PubKey      *string `json:"pubkey,omitempty"`
+	NSFW        bool    `json:"nsfw,omitempty"`
 }
 
 // V2Claim is the second version of claim metadata used by lbry.
@@ -72,13 +78,121 @@ type Sources struct {
 	LbrySDHash string `json:"lbry_sd_hash"` //Required
 	BTIH       string `json:"btih"`         //Required
 	URL        string `json:"url"`          //Required
+	IPFSCID    string `json:"ipfs_cid,omitempty"`
 }
 
-// Fee is the structure used for different currencies allowed for claims.
-type Fee struct {
-	LBC *FeeInfo `json:"LBC,omitempty"`
-	BTC *FeeInfo `json:"BTC,omitempty"`
-	USD *FeeInfo `json:"USD,omitempty"`
+// Fee is the fee of a json claim keyed by currency code, e.g. {"LBC": {"amount": 1, "address": "..."}}. A valid fee
+// names a single currency from FeeCurrencies.
+type Fee map[string]*FeeInfo
+
+// FeeCurrencies maps the currency codes of json claim fees to their protobuf currency. Supporting another currency
+// takes an entry here and one in FeePrecisions.
+var FeeCurrencies = map[string]pb.Fee_Currency{
+	"LBC": pb.Fee_LBC,
+	"BTC": pb.Fee_BTC,
+	"USD": pb.Fee_USD,
+}
+
+// SupportedCurrencies returns the currency codes in FeeCurrencies, sorted.
+func SupportedCurrencies() []string {
+	codes := make([]string, 0, len(FeeCurrencies))
+	for code := range FeeCurrencies {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// currencyCode returns the json currency code of a protobuf currency.
+func currencyCode(currency pb.Fee_Currency) (string, bool) {
+	for code, c := range FeeCurrencies {
+		if c == currency {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// FeePrecisions is how many units of each currency's protobuf fee amount make up one of the currency. Amounts are
+// stored the way lbry-sdk publishes them: LBC in dewies, BTC in satoshis and USD in cents.
+var FeePrecisions = map[pb.Fee_Currency]uint64{
+	pb.Fee_LBC: dewiesPerLBC,
+	pb.Fee_BTC: 100000000,
+	pb.Fee_USD: 100,
+}
+
+// feeAmount converts a json fee amount to the protobuf amount of its currency, rounded to the nearest unit. It is 0 for
+// a currency that isn't in FeePrecisions.
+func feeAmount(currency pb.Fee_Currency, amount float32) uint64 {
+	return uint64(math.Round(float64(amount) * float64(FeePrecisions[currency])))
+}
+
+// feeValue is the inverse of feeAmount. It returns false for a currency that isn't in FeePrecisions.
+func feeValue(currency pb.Fee_Currency, amount uint64) (float64, bool) {
+	precision, ok := FeePrecisions[currency]
+	if !ok {
+		return 0, false
+	}
+	return float64(amount) / float64(precision), true
+}
+
+// ErrUnknownCurrency is returned for a fee in a currency that isn't in FeeCurrencies.
+type ErrUnknownCurrency struct {
+	Currency string
+}
+
+func (e ErrUnknownCurrency) Error() string {
+	return fmt.Sprintf("unknown fee currency '%s'", e.Currency)
+}
+
+// UnmarshalJSON rejects fees that specify more than one currency, an unknown currency, a negative amount, or an
+// amount without an address.
+func (f *Fee) UnmarshalJSON(value []byte) error {
+	var fee map[string]*FeeInfo
+	err := json.Unmarshal(value, &fee)
+	if err != nil {
+		return err
+	}
+	_, _, err = Fee(fee).currency()
+	if err != nil {
+		return err
+	}
+	*f = fee
+	return nil
+}
+
+// currency returns the single currency the fee is in along with its amount and address. A fee that names no currency
+// is a free LBC fee, which is how older claims represent an empty fee object.
+func (f Fee) currency() (pb.Fee_Currency, FeeInfo, error) {
+	var codes []string
+	for code, info := range f {
+		if info != nil {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return pb.Fee_LBC, FeeInfo{}, nil
+	}
+	sort.Strings(codes)
+	if len(codes) > 1 {
+		return pb.Fee_UNKNOWN_CURRENCY, FeeInfo{}, errors.Err("fee specifies more than one currency (%s)", strings.Join(codes, " and "))
+	}
+
+	code, info := codes[0], *f[codes[0]]
+	currency, ok := FeeCurrencies[code]
+	if !ok {
+		return pb.Fee_UNKNOWN_CURRENCY, info, errors.Err(ErrUnknownCurrency{Currency: code})
+	}
+	if _, ok := FeePrecisions[currency]; !ok {
+		return currency, info, errors.Err("fee currency %s has no precision", code)
+	}
+	if info.Amount < 0 {
+		return currency, info, errors.Err("fee amount %v is negative", info.Amount)
+	}
+	if info.Amount > 0 && info.Address == "" {
+		return currency, info, errors.Err("fee amount %v has no address", info.Amount)
+	}
+	return currency, info, nil
 }
 
 // Unmarshal is an implementation to unmarshal the V1 claim from json. Main addition is to check the version.
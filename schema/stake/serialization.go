@@ -1,6 +1,7 @@
 package stake
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 
 	"github.com/lbryio/lbry.go/v2/extras/errors"
@@ -22,7 +23,38 @@ func (c *StakeHelper) serialized() ([]byte, error) {
 		return proto.Marshal(c.getSupportProtobuf())
 	}
 
-	return proto.Marshal(c.getClaimProtobuf())
+	return CanonicalBytes(c.getClaimProtobuf())
+}
+
+// CanonicalBytes serializes a claim with unknown fields left out and the deterministic option of the marshaler set, so
+// that a claim always serializes to the same bytes. The order of the fields is whatever golang/protobuf writes, which
+// puts the stream or channel last, and is pinned by the canonical bytes in the tests. Signing a claim signs these
+// bytes. Verifying a signature uses the payload exactly as it was published instead, since claims made by other
+// clients may not be canonical.
+func CanonicalBytes(claim *pb.Claim) ([]byte, error) {
+	if claim == nil {
+		return nil, errors.Err("claim is nil")
+	}
+	clone := proto.Clone(claim)
+	proto.DiscardUnknown(clone)
+
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	err := buf.Marshal(clone)
+	if err != nil {
+		return nil, errors.Err(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Hash is the sha256 of the claim's CanonicalBytes, for use as a key when deduplicating claims.
+func Hash(claim *pb.Claim) ([]byte, error) {
+	canonical, err := CanonicalBytes(claim)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(canonical)
+	return hash[:], nil
 }
 
 func (c *StakeHelper) getClaimProtobuf() *pb.Claim {
@@ -100,6 +132,6 @@ func (c *StakeHelper) serializedNoSignature() ([]byte, error) {
 		}
 		clone := &pb.Claim{}
 		proto.Merge(clone, c.getClaimProtobuf())
-		return proto.Marshal(clone)
+		return CanonicalBytes(clone)
 	}
 }
@@ -0,0 +1,59 @@
+package stake
+
+import (
+	"unicode/utf8"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// MaxSerializedSize is the largest claim value, in bytes, that lbrycrd accepts.
+const MaxSerializedSize = 8192
+
+// signatureHeaderLength is what signing adds in front of the protobuf: the version byte, channel claim id and signature.
+const signatureHeaderLength = 1 + 20 + 64
+
+// SerializedSize is the size of the claim value once it is compiled with a signature. Signatures are always counted so
+// that a claim that fits can still be published in a channel.
+func SerializedSize(claim *pb.Claim) int {
+	return signatureHeaderLength + proto.Size(claim)
+}
+
+// CheckSize returns an error if the claim's serialized value would be larger than MaxSerializedSize.
+func CheckSize(claim *pb.Claim) error {
+	if claim == nil {
+		return errors.Err("claim is nil")
+	}
+	if size := SerializedSize(claim); size > MaxSerializedSize {
+		return errors.Err("claim is %d bytes, %d more than the maximum of %d", size, size-MaxSerializedSize, MaxSerializedSize)
+	}
+	return nil
+}
+
+// TruncateToFit shortens the claim's description, never the title or source, until the claim passes CheckSize. It
+// returns how many bytes of the description were cut. The description is cut on a character boundary, and left as
+// it was if removing it entirely isn't enough.
+func TruncateToFit(claim *pb.Claim) (int, error) {
+	if claim == nil {
+		return 0, errors.Err("claim is nil")
+	}
+
+	original := claim.Description
+	for over := SerializedSize(claim) - MaxSerializedSize; over > 0; over = SerializedSize(claim) - MaxSerializedSize {
+		if claim.Description == "" {
+			claim.Description = original
+			return 0, errors.Err("claim is %d bytes over the maximum of %d without a description", over, MaxSerializedSize)
+		}
+		cut := len(claim.Description) - over
+		if cut < 0 {
+			cut = 0
+		}
+		for cut > 0 && !utf8.RuneStart(claim.Description[cut]) {
+			cut--
+		}
+		claim.Description = claim.Description[:cut]
+	}
+	return len(original) - len(claim.Description), nil
+}
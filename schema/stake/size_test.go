@@ -0,0 +1,61 @@
+package stake
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+// claimOfSize returns a valid stream claim whose serialized size is exactly size, padded with its description.
+func claimOfSize(t *testing.T, size int) *pb.Claim {
+	claim := validStreamClaim(t)
+	claim.Description = strings.Repeat("a", size-SerializedSize(claim))
+	for SerializedSize(claim) > size {
+		claim.Description = claim.Description[:len(claim.Description)-1]
+	}
+	assert.Equal(t, SerializedSize(claim), size)
+	return claim
+}
+
+func TestCheckSize(t *testing.T) {
+	assert.NilError(t, CheckSize(validStreamClaim(t)))
+	assert.NilError(t, CheckSize(claimOfSize(t, MaxSerializedSize)))
+	assert.ErrorContains(t, CheckSize(claimOfSize(t, MaxSerializedSize+1)), "1 more than the maximum")
+	assert.Assert(t, CheckSize(nil) != nil)
+}
+
+func TestTruncateToFit(t *testing.T) {
+	claim := claimOfSize(t, MaxSerializedSize)
+	cut, err := TruncateToFit(claim)
+	assert.NilError(t, err)
+	assert.Equal(t, cut, 0)
+
+	claim = claimOfSize(t, MaxSerializedSize+100)
+	title, description := claim.GetTitle(), claim.GetDescription()
+	cut, err = TruncateToFit(claim)
+	assert.NilError(t, err)
+	assert.Equal(t, cut, 100)
+	assert.Equal(t, claim.GetDescription(), description[:len(description)-100])
+	assert.Equal(t, claim.GetTitle(), title)
+	assert.NilError(t, CheckSize(claim))
+
+	// multi byte characters are never split
+	claim = validStreamClaim(t)
+	claim.Description = strings.Repeat("説", MaxSerializedSize/3)
+	_, err = TruncateToFit(claim)
+	assert.NilError(t, err)
+	assert.NilError(t, CheckSize(claim))
+	assert.Assert(t, utf8.ValidString(claim.GetDescription()))
+
+	// a title that doesn't fit on its own is an error and the claim is left alone
+	claim = validStreamClaim(t)
+	claim.Title = strings.Repeat("a", MaxSerializedSize)
+	claim.Description = "description"
+	_, err = TruncateToFit(claim)
+	assert.ErrorContains(t, err, "without a description")
+	assert.Equal(t, claim.GetDescription(), "description")
+}
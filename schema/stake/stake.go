@@ -239,37 +239,12 @@ func DecodeClaimBytes(serialized []byte, blockchainName string) (*StakeHelper, e
 	if err == nil {
 		return helper, nil
 	}
-	helper = &StakeHelper{}
 	//If protobuf fails, try json versions before returning an error.
-	v1Claim := new(V1Claim)
-	err = v1Claim.Unmarshal(serialized)
+	claim, err := MigrateFromJSONWithOptions(serialized, MigrationOptions{BlockchainName: blockchainName})
 	if err != nil {
-		v2Claim := new(V2Claim)
-		err := v2Claim.Unmarshal(serialized)
-		if err != nil {
-			v3Claim := new(V3Claim)
-			err := v3Claim.Unmarshal(serialized)
-			if err != nil {
-				return nil, errors.Prefix("Claim value has no matching version", err)
-			}
-			helper.Claim, err = migrateV3Claim(*v3Claim)
-			if err != nil {
-				return nil, errors.Prefix("V3 Metadata Migration Error", err)
-			}
-			return helper, nil
-		}
-		helper.Claim, err = migrateV2Claim(*v2Claim)
-		if err != nil {
-			return nil, errors.Prefix("V2 Metadata Migration Error ", err)
-		}
-		return helper, nil
-	}
-
-	helper.Claim, err = migrateV1Claim(*v1Claim)
-	if err != nil {
-		return nil, errors.Prefix("V1 Metadata Migration Error ", err)
+		return nil, err
 	}
-	return helper, nil
+	return &StakeHelper{Claim: claim}, nil
 }
 
 // DecodeSupportBytes take a byte array and tries to decode it to a protobuf support
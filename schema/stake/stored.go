@@ -0,0 +1,83 @@
+package stake
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	v1pb "github.com/lbryio/types/v1/go"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// CurrentClaimVersion is the version MigrateStoredClaim reports for claims that are already in the current protobuf
+// schema. It has the same number as VersionProtobufHex.
+const CurrentClaimVersion = int(VersionProtobufHex)
+
+// MigrateStoredClaim migrates a claim value as it is stored on the blockchain to the current schema and returns the
+// version it was stored in. Json claims are V1, V2 or V3 by their "ver" field. Legacy protobuf claims are V1, V2 or V3
+// by the version of their metadata, with 0.1.0, the protobuf form of V3, counting as V3, and legacy channels are V1.
+// Current protobuf claims are returned as they are with CurrentClaimVersion. Protobuf claims can be binary or hex
+// encoded. Fee addresses are checked against the network of blockchainName, as DecodeClaimBytes does.
+func MigrateStoredClaim(raw []byte, blockchainName string) (*pb.Claim, int, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, 0, errors.Err("there is nothing to migrate")
+	}
+
+	if trimmed[0] == '{' {
+		version, err := DetectVersion(trimmed)
+		if err != nil {
+			return nil, 0, err
+		}
+		claim, err := MigrateFromJSONWithOptions(trimmed, MigrationOptions{BlockchainName: blockchainName})
+		if err != nil {
+			return nil, int(version), err
+		}
+		return claim, int(version), nil
+	}
+
+	value := raw
+	if isProtobufHex(trimmed) {
+		value = make([]byte, hex.DecodedLen(len(trimmed)))
+		if _, err := hex.Decode(value, trimmed); err != nil {
+			return nil, 0, errors.Err(err)
+		}
+	}
+	helper, err := DecodeClaimProtoBytes(value, blockchainName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if helper.LegacyClaim != nil {
+		return helper.Claim, legacyClaimVersion(helper.LegacyClaim), nil
+	}
+	return helper.Claim, CurrentClaimVersion, nil
+}
+
+// legacyClaimVersion is the json version a legacy protobuf claim corresponds to.
+func legacyClaimVersion(claim *v1pb.Claim) int {
+	switch claim.GetStream().GetMetadata().GetVersion() {
+	case v1pb.Metadata__0_0_2:
+		return int(VersionV2)
+	case v1pb.Metadata__0_0_3, v1pb.Metadata__0_1_0:
+		return int(VersionV3)
+	}
+	return int(VersionV1)
+}
+
+// Add counts a claim of version whose migration failed with err, or succeeded if err is nil. The zero value of
+// MigrationStats is ready to count.
+func (s *MigrationStats) Add(version ClaimVersion, err error) {
+	if s.ByVersion == nil {
+		s.ByVersion = make(map[ClaimVersion]int)
+	}
+	if s.ByError == nil {
+		s.ByError = make(map[string]int)
+	}
+	s.ByVersion[version]++
+	if err != nil {
+		s.Failed++
+		s.ByError[errorKind(err)]++
+	} else {
+		s.Migrated++
+	}
+}
@@ -0,0 +1,89 @@
+package stake
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestMigrateStoredClaim(t *testing.T) {
+	stream := &StakeHelper{Claim: validStreamClaim(t), Version: NoSig}
+	current, err := stream.CompileValue()
+	assert.NilError(t, err)
+	legacyStream, err := hex.DecodeString("080110011ad6010801127c080410011a08727067206d69646922046d6964692a08727067206d696469322e437265617469766520436f6d6d6f6e73204174747269627574696f6e20342e3020496e7465726e6174696f6e616c38004224080110011a19553f00bc139bbf40de425f94d51fffb34c1bea6d9171cd374c25000070414a0052005a001a54080110011a301f41eb0312aa7e8a5ce49349bc77d811da975833719d751523b19f123fc3d528d6a94e3446ccddb7b9329f27a9cad7e3221c6170706c69636174696f6e2f782d7a69702d636f6d70726573736564")
+	assert.NilError(t, err)
+	legacyChannel, err := hex.DecodeString("08011002225e0801100322583056301006072a8648ce3d020106052b8104000a034200043878b1edd4a1373149909ef03f4339f6da9c2bd2214c040fd2e530463ffe66098eca14fc70b50ff3aefd106049a815f595ed5a13eda7419ad78d9ed7ae473f17")
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name    string
+		raw     []byte
+		version int
+		title   string
+		channel bool
+	}{
+		{"json v1", []byte(`{"title": "v1", "language": "en", "content-type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), 1, "v1", false},
+		{"json v2", []byte(`{"ver": "0.0.2", "title": "v2", "language": "en", "content-type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), 2, "v2", false},
+		{"json v3", []byte(` {"ver": "0.0.3", "title": "v3", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), 3, "v3", false},
+		{"legacy protobuf stream", legacyStream, 3, "rpg midi", false},
+		{"legacy protobuf stream hex", []byte(hex.EncodeToString(legacyStream)), 3, "rpg midi", false},
+		{"legacy protobuf channel", legacyChannel, 1, "", true},
+		{"current protobuf", current, CurrentClaimVersion, validStreamClaim(t).GetTitle(), false},
+		{"current protobuf hex", []byte(hex.EncodeToString(current)), CurrentClaimVersion, validStreamClaim(t).GetTitle(), false},
+	}
+	for _, test := range tests {
+		claim, version, err := MigrateStoredClaim(test.raw, "lbrycrd_main")
+		assert.NilError(t, err, test.name)
+		assert.Equal(t, version, test.version, test.name)
+		if test.channel {
+			assert.Assert(t, claim.GetChannel() != nil, test.name)
+		} else {
+			assert.Assert(t, claim.GetStream() != nil, test.name)
+			assert.Equal(t, claim.GetTitle(), test.title, test.name)
+		}
+	}
+}
+
+func TestMigrateStoredClaimNetwork(t *testing.T) {
+	raw := []byte(`{"ver": "0.0.3", "title": "testnet", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"LBC": {"amount": 1, "address": "mgAFts2ZXGnZnDFGZbqESNDsWcAHVZMVjM"}}}`)
+	for _, blockchainName := range []string{"lbrycrd_testnet", "lbrycrd_regtest"} {
+		claim, version, err := MigrateStoredClaim(raw, blockchainName)
+		assert.NilError(t, err, blockchainName)
+		assert.Equal(t, version, 3, blockchainName)
+		assert.Assert(t, claim.GetStream().GetFee() != nil, blockchainName)
+	}
+
+	_, _, err := MigrateStoredClaim(raw, "lbrycrd_main")
+	assert.Assert(t, err != nil)
+}
+
+func TestMigrateStoredClaimErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []byte
+		version int
+	}{
+		{"empty", []byte("  "), 0},
+		{"unknown json version", []byte(`{"ver": "0.0.9", "title": "future"}`), 0},
+		{"invalid json claim", []byte(`{"ver": "0.0.3", "title": "", "language": "en", "content_type": "video/mp4", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`), 3},
+		{"garbage", []byte("not a claim"), 0},
+	}
+	for _, test := range tests {
+		claim, version, err := MigrateStoredClaim(test.raw, "lbrycrd_main")
+		assert.Assert(t, err != nil, test.name)
+		assert.Assert(t, claim == nil, test.name)
+		assert.Equal(t, version, test.version, test.name)
+	}
+}
+
+func TestMigrationStatsAdd(t *testing.T) {
+	var stats MigrationStats
+	stats.Add(VersionV1, nil)
+	stats.Add(VersionV3, nil)
+	stats.Add(VersionV3, ErrUnknownLanguage{Language: "engrish"})
+	assert.Equal(t, stats.Migrated, 2)
+	assert.Equal(t, stats.Failed, 1)
+	assert.DeepEqual(t, stats.ByVersion, map[ClaimVersion]int{VersionV1: 1, VersionV3: 2})
+	assert.DeepEqual(t, stats.ByError, map[string]int{"unknown language": 1})
+}
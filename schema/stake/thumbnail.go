@@ -0,0 +1,63 @@
+package stake
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+
+	"golang.org/x/net/idna"
+)
+
+// MaxThumbnailURLLength is the longest thumbnail url, after normalization, that a claim can have.
+const MaxThumbnailURLLength = 2048
+
+// NormalizeThumbnailURL checks that a thumbnail is an absolute http or https url and returns it in a canonical form:
+// the scheme and host lower cased, an internationalized host in its punycode form and characters such as spaces
+// escaped. Relative and protocol relative urls are rejected, as are data: and javascript: urls.
+func NormalizeThumbnailURL(thumbnail string) (string, error) {
+	trimmed := strings.TrimSpace(thumbnail)
+	if trimmed == "" {
+		return "", errors.Err("thumbnail url is empty")
+	}
+
+	u, err := url.Parse(trimmed)
+	if err != nil {
+		return "", errors.Prefix(fmt.Sprintf("thumbnail url '%s' is not a url", thumbnail), err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	case "":
+		return "", errors.Err("thumbnail url '%s' is not absolute", thumbnail)
+	case "data", "javascript":
+		return "", errors.Err("thumbnail url can't be a %s: url", strings.ToLower(u.Scheme))
+	default:
+		return "", errors.Err("thumbnail url '%s' is not http or https", thumbnail)
+	}
+	if u.Hostname() == "" {
+		return "", errors.Err("thumbnail url '%s' has no host", thumbnail)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if net.ParseIP(host) == nil {
+		host, err = idna.Lookup.ToASCII(host)
+		if err != nil {
+			return "", errors.Prefix(fmt.Sprintf("thumbnail url '%s' has an invalid host", thumbnail), err)
+		}
+	}
+	if port := u.Port(); port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = host
+
+	normalized := u.String()
+	if len(normalized) > MaxThumbnailURLLength {
+		return "", errors.Err("thumbnail url is %d bytes, more than the maximum of %d", len(normalized), MaxThumbnailURLLength)
+	}
+	return normalized, nil
+}
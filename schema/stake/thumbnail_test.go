@@ -0,0 +1,72 @@
+package stake
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNormalizeThumbnailURL(t *testing.T) {
+	tests := []struct {
+		Thumbnail  string
+		Normalized string
+	}{
+		{"https://example.com/thumbnail.jpg", "https://example.com/thumbnail.jpg"},
+		{"HTTPS://Example.COM/Thumbnail.jpg", "https://example.com/Thumbnail.jpg"},
+		{" http://example.com/a.png ", "http://example.com/a.png"},
+		{"https://example.com:8443/a.png?size=large", "https://example.com:8443/a.png?size=large"},
+		{"https://example.com/my thumbnail.jpg", "https://example.com/my%20thumbnail.jpg"},
+		{"https://xn--bcher-kva.example/cover.jpg", "https://xn--bcher-kva.example/cover.jpg"},
+		{"https://XN--BCHER-KVA.example/cover.jpg", "https://xn--bcher-kva.example/cover.jpg"},
+		{"https://b\u00fccher.example/cover.jpg", "https://xn--bcher-kva.example/cover.jpg"},
+		{"http://127.0.0.1:8080/a.jpg", "http://127.0.0.1:8080/a.jpg"},
+		{"http://[::1]/a.jpg", "http://[::1]/a.jpg"},
+	}
+	for _, test := range tests {
+		normalized, err := NormalizeThumbnailURL(test.Thumbnail)
+		assert.NilError(t, err, test.Thumbnail)
+		assert.Equal(t, normalized, test.Normalized)
+	}
+
+	invalid := []struct {
+		Thumbnail string
+		Message   string
+	}{
+		{"", "is empty"},
+		{"image.jpg", "is not absolute"},
+		{"/images/image.jpg", "is not absolute"},
+		{"//example.com/image.jpg", "is not absolute"},
+		{"data:image/png;base64,iVBORw0KGgo=", "can't be a data: url"},
+		{"javascript:alert(1)", "can't be a javascript: url"},
+		{"JavaScript:alert(1)", "can't be a javascript: url"},
+		{"ftp://example.com/image.jpg", "is not http or https"},
+		{"https:///image.jpg", "has no host"},
+		{"https://exa mple.com/image.jpg", "is not a url"},
+		{"https://example.com/" + strings.Repeat("a", MaxThumbnailURLLength), "more than the maximum"},
+	}
+	for _, test := range invalid {
+		_, err := NormalizeThumbnailURL(test.Thumbnail)
+		assert.ErrorContains(t, err, test.Message, test.Thumbnail)
+	}
+}
+
+func TestClaimBuilderThumbnail(t *testing.T) {
+	claim, err := validBuilder().Thumbnail("HTTPS://Example.com/my thumbnail.jpg").Build()
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetThumbnail().GetUrl(), "https://example.com/my%20thumbnail.jpg")
+
+	_, err = validBuilder().Thumbnail("image.jpg").Build()
+	assert.ErrorContains(t, err, "claim 'title': thumbnail url 'image.jpg' is not absolute")
+}
+
+func TestMigrateInvalidThumbnail(t *testing.T) {
+	value := []byte(`{"ver": "0.0.3", "title": "old", "thumbnail": "data:image/png;base64,iVBORw0KGgo=", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`)
+
+	claim, err := MigrateFromJSON(value)
+	assert.NilError(t, err)
+	assert.Equal(t, claim.GetThumbnail().GetUrl(), "data:image/png;base64,iVBORw0KGgo=")
+
+	_, err = MigrateFromJSONWithOptions(value, MigrationOptions{Strict: true})
+	assert.ErrorContains(t, err, "can't be a data: url")
+}
@@ -0,0 +1,135 @@
+package stake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+)
+
+// v1Fields are the fields of V1Claim, anything else in a V1 claim is ignored.
+var v1Fields = map[string]bool{
+	"ver": true, "title": true, "description": true, "author": true, "language": true, "license": true,
+	"sources": true, "content-type": true, "thumbnail": true, "fee": true, "contact": true, "pubkey": true, "nsfw": true,
+}
+
+// UnmarshalTolerant works like Unmarshal, but first fixes up the values real V1 claims commonly get wrong: numbers
+// written as strings, flags written as 0 or 1 and sources given as a bare sd hash. Unknown fields are ignored. It
+// returns a description of every change it made, so the claims that needed them can be audited. Documents that are
+// malformed in any other way are still rejected.
+func (c *V1Claim) UnmarshalTolerant(value []byte) ([]string, error) {
+	normalized, warnings, err := normalizeV1JSON(value)
+	if err != nil {
+		return nil, err
+	}
+	err = c.Unmarshal(normalized)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+func normalizeV1JSON(value []byte) ([]byte, []string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	err := decoder.Decode(&fields)
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+
+	var warnings []string
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !v1Fields[name] {
+			warnings = append(warnings, fmt.Sprintf("ignored unknown field '%s'", name))
+		}
+	}
+
+	if nsfw, ok := fields["nsfw"]; ok {
+		if coerced, ok := coerceBool(nsfw); ok {
+			fields["nsfw"] = coerced
+			warnings = append(warnings, fmt.Sprintf("nsfw: coerced %s to %t", jsonString(nsfw), coerced))
+		}
+	}
+	if contact, ok := fields["contact"]; ok {
+		if coerced, ok := coerceNumber(contact); ok {
+			fields["contact"] = coerced
+			warnings = append(warnings, fmt.Sprintf("contact: coerced %s to %s", jsonString(contact), coerced))
+		}
+	}
+	if sdHash, ok := fields["sources"].(string); ok {
+		fields["sources"] = map[string]interface{}{"lbry_sd_hash": sdHash}
+		warnings = append(warnings, "sources: coerced a bare sd hash to lbry_sd_hash")
+	}
+	if fee, ok := fields["fee"].(map[string]interface{}); ok {
+		currencies := make([]string, 0, len(fee))
+		for currency := range fee {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			info, ok := fee[currency].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if coerced, ok := coerceNumber(info["amount"]); ok {
+				warnings = append(warnings, fmt.Sprintf("fee.%s.amount: coerced %s to %s", currency, jsonString(info["amount"]), coerced))
+				info["amount"] = coerced
+			}
+		}
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, errors.Err(err)
+	}
+	return normalized, warnings, nil
+}
+
+// coerceBool turns 0, 1 and their string forms, or "true" and "false", into a bool. It returns false if value is
+// already a bool or can't be coerced.
+func coerceBool(value interface{}) (bool, bool) {
+	var raw string
+	switch v := value.(type) {
+	case json.Number:
+		raw = v.String()
+	case string:
+		raw = strings.ToLower(strings.TrimSpace(v))
+	default:
+		return false, false
+	}
+	switch raw {
+	case "1", "true":
+		return true, true
+	case "0", "false":
+		return false, true
+	}
+	return false, false
+}
+
+// coerceNumber turns a string holding a number into that number. It returns false for anything else.
+func coerceNumber(value interface{}) (json.Number, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return "", false
+	}
+	s = strings.TrimSpace(s)
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return "", false
+	}
+	return json.Number(s), true
+}
+
+func jsonString(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
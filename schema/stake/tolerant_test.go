@@ -0,0 +1,87 @@
+package stake
+
+import (
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestUnmarshalTolerant(t *testing.T) {
+	value := []byte(`{"ver": "0.0.1", "title": "dirty", "description": "", "author": "", "language": "en", "license": "",
+		"content-type": "video/mp4", "nsfw": 1, "contact": "7", "uploaded_by": "someone",
+		"sources": "` + testSDHash + `",
+		"fee": {"LBC": {"amount": " 2.5", "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`)
+
+	v1Claim := new(V1Claim)
+	warnings, err := v1Claim.UnmarshalTolerant(value)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, warnings, []string{
+		"ignored unknown field 'uploaded_by'",
+		"nsfw: coerced 1 to true",
+		`contact: coerced "7" to 7`,
+		"sources: coerced a bare sd hash to lbry_sd_hash",
+		`fee.LBC.amount: coerced " 2.5" to 2.5`,
+	})
+	assert.Assert(t, v1Claim.NSFW)
+	assert.Equal(t, *v1Claim.Contact, 7)
+	assert.Equal(t, v1Claim.Sources.LbrySDHash, testSDHash)
+	assert.Equal(t, (*v1Claim.Fee)["LBC"].Amount, float32(2.5))
+
+	claim, warnings, err := MigrateFromJSONWithWarnings(value, MigrationOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 5)
+	assert.Assert(t, IsMature(claim))
+	assert.Equal(t, claim.GetStream().GetFee().GetAmount(), uint64(250000000))
+	assert.Equal(t, claim.GetStream().GetFee().GetCurrency(), pb.Fee_LBC)
+}
+
+func TestUnmarshalTolerantFlags(t *testing.T) {
+	tests := []struct {
+		NSFW     string
+		Expected bool
+		Warning  bool
+	}{
+		{`true`, true, false},
+		{`false`, false, false},
+		{`1`, true, true},
+		{`0`, false, true},
+		{`"1"`, true, true},
+		{`"False"`, false, true},
+	}
+	for _, test := range tests {
+		value := []byte(`{"title": "flag", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "nsfw": ` + test.NSFW + `}`)
+		v1Claim := new(V1Claim)
+		warnings, err := v1Claim.UnmarshalTolerant(value)
+		assert.NilError(t, err, test.NSFW)
+		assert.Equal(t, v1Claim.NSFW, test.Expected, test.NSFW)
+		assert.Equal(t, len(warnings) == 1, test.Warning, test.NSFW)
+	}
+}
+
+func TestUnmarshalTolerantClean(t *testing.T) {
+	value := []byte(`{"title": "clean", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content-type": "video/mp4", "fee": {"LBC": {"amount": 1, "address": "bUc9gyCJPKu2CBYpTvJ98MdmsLb68utjP6"}}}`)
+	_, warnings, err := MigrateFromJSONWithWarnings(value, MigrationOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, warnings == nil)
+}
+
+func TestUnmarshalTolerantMalformed(t *testing.T) {
+	tests := []string{
+		`not json`,
+		`["title"]`,
+		`{"title": 5, "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		`{"title": "a", "nsfw": 2, "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		`{"title": "a", "nsfw": "maybe", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		`{"title": "a", "contact": "seven", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		`{"title": "a", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "fee": {"LBC": {"amount": "free", "address": ""}}}`,
+		`{"title": "a", "sources": ["` + testSDHash + `"]}`,
+		`{"ver": "0.0.2", "title": "a", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+	}
+	for _, value := range tests {
+		v1Claim := new(V1Claim)
+		_, err := v1Claim.UnmarshalTolerant([]byte(value))
+		assert.Assert(t, err != nil, value)
+	}
+}
@@ -0,0 +1,190 @@
+package stake
+
+import (
+	"encoding/hex"
+	"mime"
+	"strings"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+)
+
+const (
+	sdHashLength  = 48
+	addressLength = 25
+
+	// maxVideoDuration and maxVideoDimension are far above any real video, they only catch values that are garbage.
+	maxVideoDuration  = 7 * 24 * 60 * 60
+	maxVideoDimension = 16384
+)
+
+// ValidationErrors lists every rule a claim violates, so callers can report all of them at once.
+type ValidationErrors []error
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, err := range v {
+		messages[i] = err.Error()
+	}
+	return "invalid claim: " + strings.Join(messages, "; ")
+}
+
+// Validate checks that a claim has the fields the schema requires. All violations are collected and returned as
+// ValidationErrors rather than stopping at the first one. Authors are held to MaxAuthorLength.
+func Validate(claim *pb.Claim) error {
+	return validate(claim, MigrationOptions{}, MaxAuthorLength)
+}
+
+// validate works like Validate, with opts relaxing the checks migration was told to be lenient about and authorLimit
+// the longest author allowed, or 0 for no limit.
+func validate(claim *pb.Claim, opts MigrationOptions, authorLimit int) error {
+	if claim == nil {
+		return errors.Err("claim is nil")
+	}
+
+	var violations ValidationErrors
+	if claim.GetStream() != nil {
+		violations = append(violations, validateStream(claim, opts, authorLimit)...)
+	} else if claim.GetChannel() != nil {
+		if len(claim.GetChannel().GetPublicKey()) == 0 {
+			violations = append(violations, errors.Base("channel has no public key"))
+		}
+	} else if claim.GetRepost() != nil {
+		if claimHash := claim.GetRepost().GetClaimHash(); len(claimHash) != claimIDLength {
+			violations = append(violations, errors.Base("reposted claim id is %d bytes, expected %d", len(claimHash), claimIDLength))
+		}
+	} else if claim.GetCollection() != nil {
+		for i, reference := range claim.GetCollection().GetClaimReferences() {
+			if len(reference.GetClaimHash()) != claimIDLength {
+				violations = append(violations, errors.Base("collection item %d is %d bytes, expected %d", i, len(reference.GetClaimHash()), claimIDLength))
+			}
+		}
+		if size := SerializedSize(claim); size > MaxSerializedSize {
+			violations = append(violations, errors.Base("collection is %d bytes, more than the maximum of %d", size, MaxSerializedSize))
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.Err(violations)
+	}
+	return nil
+}
+
+func validateStream(claim *pb.Claim, opts MigrationOptions, authorLimit int) []error {
+	var violations []error
+	stream := claim.GetStream()
+
+	if strings.TrimSpace(claim.GetTitle()) == "" {
+		violations = append(violations, errors.Base("title is empty"))
+	}
+	if authorLimit > 0 && len(stream.GetAuthor()) > authorLimit {
+		violations = append(violations, errors.Base("author is longer than %d bytes", authorLimit))
+	}
+
+	source := stream.GetSource()
+	if source == nil {
+		violations = append(violations, errors.Base("stream has no source"))
+	} else {
+		if len(source.GetSdHash()) != sdHashLength && !opts.AllowMalformedSDHash {
+			violations = append(violations, errors.Base("sd hash is %d bytes, expected %d", len(source.GetSdHash()), sdHashLength))
+		}
+		if err := validateMediaType(source.GetMediaType()); err != nil {
+			violations = append(violations, err)
+		} else if err := validateStreamType(stream); err != nil {
+			violations = append(violations, err)
+		}
+	}
+	if video := stream.GetVideo(); video != nil {
+		if video.GetDuration() > maxVideoDuration {
+			violations = append(violations, errors.Base("video duration %d is more than %d seconds", video.GetDuration(), maxVideoDuration))
+		}
+		if video.GetWidth() > maxVideoDimension || video.GetHeight() > maxVideoDimension {
+			violations = append(violations, errors.Base("video is %dx%d, more than %d pixels a side", video.GetWidth(), video.GetHeight(), maxVideoDimension))
+		}
+	}
+
+	if fee := stream.GetFee(); fee != nil {
+		if fee.GetCurrency() == pb.Fee_UNKNOWN_CURRENCY {
+			violations = append(violations, errors.Base("fee has no currency"))
+		}
+		if fee.GetAmount() > 0 && len(fee.GetAddress()) != addressLength {
+			violations = append(violations, errors.Base("fee address is %d bytes, expected %d", len(fee.GetAddress()), addressLength))
+		}
+	}
+
+	return violations
+}
+
+// documentMediaTypes are the content types, besides text/*, that a stream published as a document can have.
+var documentMediaTypes = map[string]bool{
+	"application/pdf":                         true,
+	"application/epub+zip":                    true,
+	"application/msword":                      true,
+	"application/rtf":                         true,
+	"application/vnd.oasis.opendocument.text": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+}
+
+// validateStreamType checks that the audio, video or image metadata of a stream matches the family of its content
+// type. Streams without metadata can have any content type.
+func validateStreamType(stream *pb.Stream) error {
+	var family string
+	switch stream.GetType().(type) {
+	case *pb.Stream_Audio:
+		family = "audio"
+	case *pb.Stream_Video:
+		family = "video"
+	case *pb.Stream_Image:
+		family = "image"
+	default:
+		return nil
+	}
+	mediaType := stream.GetSource().GetMediaType()
+	if mediaTypeFamily(mediaType) != family {
+		return errors.Base("%s metadata does not match content type '%s'", family, mediaType)
+	}
+	return nil
+}
+
+// validateDocumentType checks that a stream's content type is one documents are published with.
+func validateDocumentType(mediaType string) error {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil || !(mediaTypeFamily(parsed) == "text" || documentMediaTypes[parsed]) {
+		return errors.Base("content type '%s' is not a document type", mediaType)
+	}
+	return nil
+}
+
+// mediaTypeFamily returns the part of a media type before the slash, e.g. "audio" for "audio/mpeg".
+func mediaTypeFamily(mediaType string) string {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return ""
+	}
+	return strings.SplitN(parsed, "/", 2)[0]
+}
+
+func validateMediaType(mediaType string) error {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil || !strings.Contains(parsed, "/") {
+		return errors.Base("content type '%s' is not a valid media type", mediaType)
+	}
+	return nil
+}
+
+// ValidateSDHash checks that a hex encoded SD hash is well formed and decodes to exactly sdHashLength bytes.
+func ValidateSDHash(sdHash string) error {
+	if sdHash == "" {
+		return errors.Err("sd hash is empty")
+	}
+	if len(sdHash)%2 != 0 {
+		return errors.Err("sd hash has an odd number of hex characters (%d)", len(sdHash))
+	}
+	if _, err := hex.DecodeString(sdHash); err != nil {
+		return errors.Prefix("sd hash is not hex", err)
+	}
+	if len(sdHash) != sdHashLength*2 {
+		return errors.Err("sd hash is %d hex characters, expected %d", len(sdHash), sdHashLength*2)
+	}
+	return nil
+}
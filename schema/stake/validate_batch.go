@@ -0,0 +1,46 @@
+package stake
+
+import (
+	"sync"
+
+	pb "github.com/lbryio/types/v2/go"
+)
+
+// ValidationResult is the outcome of validating the claim at Index of the claims given to ValidateBatch. Err is nil if
+// the claim is valid.
+type ValidationResult struct {
+	Index int
+	Claim *pb.Claim
+	Err   error
+}
+
+// ValidateBatch runs Validate on every claim with the given number of workers, fewer than one meaning one. The results
+// are in the same order as claims.
+func ValidateBatch(claims []*pb.Claim, workers int) []ValidationResult {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(claims) {
+		workers = len(claims)
+	}
+
+	results := make([]ValidationResult, len(claims))
+	indexes := make(chan int, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = ValidationResult{Index: index, Claim: claims[index], Err: Validate(claims[index])}
+			}
+		}()
+	}
+	for i := range claims {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,56 @@
+package stake
+
+import (
+	"fmt"
+	"testing"
+
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+func TestValidateBatch(t *testing.T) {
+	claims := []*pb.Claim{validStreamClaim(t), nil, validStreamClaim(t), newChannelClaim(), validStreamClaim(t)}
+	claims[4].Title = ""
+
+	for _, workers := range []int{0, 1, 3, 10} {
+		results := ValidateBatch(claims, workers)
+		assert.Equal(t, len(results), len(claims))
+		for i, result := range results {
+			assert.Equal(t, result.Index, i)
+			assert.Equal(t, result.Claim, claims[i])
+			assert.Equal(t, result.Err == nil, i == 0 || i == 2, "claim %d with %d workers: %v", i, workers, result.Err)
+		}
+	}
+	assert.Equal(t, len(ValidateBatch(nil, 8)), 0)
+}
+
+func BenchmarkValidateBatch(b *testing.B) {
+	claims := make([]*pb.Claim, 10000)
+	for i := range claims {
+		claim, err := validBuilder().Title(fmt.Sprintf("claim %d", i)).Build()
+		if err != nil {
+			b.Fatal(err)
+		}
+		claims[i] = claim
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, claim := range claims {
+				if err := Validate(claim); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("workers=8", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, result := range ValidateBatch(claims, 8) {
+				if result.Err != nil {
+					b.Fatal(result.Err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package stake
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/lbryio/lbry.go/v2/extras/errors"
+	pb "github.com/lbryio/types/v2/go"
+
+	"gotest.tools/assert"
+)
+
+const testSDHash = "bd94033d13f4f3908708701caf565bfa09cfadf2f34fadf4a73fb86b295d1b21a7e64805994e45b5fbc650f30bac4874"
+
+func validStreamClaim(t *testing.T) *pb.Claim {
+	sdHash, err := hex.DecodeString(testSDHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim := newStreamClaim()
+	claim.Title = "title"
+	claim.GetStream().Source = &pb.Source{SdHash: sdHash, MediaType: "video/mp4"}
+	return claim
+}
+
+// validBuilder returns a builder with the fields a stream claim needs, the ClaimBuilder counterpart of
+// validStreamClaim. Tests set what they need on top of it.
+func validBuilder() *ClaimBuilder {
+	return NewClaimBuilder().Title("title").SDHash(testSDHash).ContentType("video/mp4")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NilError(t, Validate(validStreamClaim(t)))
+
+	claim := validStreamClaim(t)
+	claim.Title = " "
+	claim.GetStream().Author = strings.Repeat("a", MaxAuthorLength+1)
+	claim.GetStream().GetSource().SdHash = claim.GetStream().GetSource().SdHash[:47]
+	claim.GetStream().GetSource().MediaType = "mp4"
+	claim.GetStream().Fee = &pb.Fee{Amount: 100}
+
+	err := Validate(claim)
+	violations, ok := errors.Unwrap(err).(ValidationErrors)
+	assert.Assert(t, ok, "expected ValidationErrors, got %v", err)
+	// title, author, sd hash, content type, fee currency and fee address
+	assert.Equal(t, len(violations), 6, err.Error())
+
+	claim = validStreamClaim(t)
+	claim.GetStream().Source = nil
+	assert.ErrorContains(t, Validate(claim), "stream has no source")
+
+	assert.ErrorContains(t, Validate(newChannelClaim()), "channel has no public key")
+	assert.Assert(t, Validate(nil) != nil)
+}
+
+func TestMigrationRejectsInvalidClaims(t *testing.T) {
+	tests := []string{
+		`{"ver": "0.0.3", "title": "", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content_type": "video/mp4"}`,
+		`{"ver": "0.0.3", "title": "short hash", "sources": {"lbry_sd_hash": "bd94"}, "content_type": "video/mp4"}`,
+		`{"ver": "0.0.2", "title": "no content type", "sources": {"lbry_sd_hash": "` + testSDHash + `"}}`,
+		`{"title": "fee without address", "sources": {"lbry_sd_hash": "` + testSDHash + `"}, "content-type": "video/mp4", "fee": {"LBC": {"amount": 1, "address": ""}}}`,
+	}
+	for _, test := range tests {
+		claim, err := MigrateFromJSON([]byte(test))
+		assert.Assert(t, err != nil, test)
+		assert.Assert(t, claim == nil, test)
+	}
+}
+
+func TestValidateSDHash(t *testing.T) {
+	assert.NilError(t, ValidateSDHash(testSDHash))
+	assert.NilError(t, ValidateSDHash(strings.ToUpper(testSDHash)))
+
+	tests := map[string]string{
+		"empty":     "",
+		"odd":       testSDHash[:95],
+		"truncated": testSDHash[:94],
+		"doubled":   testSDHash + testSDHash,
+		"not hex":   "zz" + testSDHash[2:],
+	}
+	for name, sdHash := range tests {
+		assert.Assert(t, ValidateSDHash(sdHash) != nil, name)
+	}
+}
+
+func TestMigrateMalformedSDHash(t *testing.T) {
+	tests := []struct {
+		SDHash string
+		Kept   int
+	}{
+		{testSDHash[:94], 47},
+		{testSDHash[:95], 95},
+		{"zz" + testSDHash[2:], 96},
+	}
+	for _, test := range tests {
+		value := []byte(`{"ver": "0.0.3", "title": "archived", "sources": {"lbry_sd_hash": "` + test.SDHash + `"}, "content_type": "video/mp4"}`)
+
+		_, err := MigrateFromJSON(value)
+		assert.ErrorContains(t, err, "claim 'archived'")
+
+		claim, err := MigrateFromJSONWithOptions(value, MigrationOptions{AllowMalformedSDHash: true})
+		assert.NilError(t, err)
+		assert.Equal(t, len(claim.GetStream().GetSource().GetSdHash()), test.Kept)
+	}
+}